@@ -81,9 +81,21 @@ func GetDNSRecords(apiKey string) (string, error) {
 	return dnsRecords, err
 }
 
-// addDNSRecord returns the JSON "result" field after using the Dreamhost API to add an IP address to a domain in dreamhost and any errors.
-func AddDNSRecord(domain string, newIPAddress string, apiKey string) (string, error) {
-	command := map[string]string{"cmd": "dns-add_record", "record": domain, "type": "A", "value": newIPAddress}
+// AddRecord returns the JSON "result" field after using the Dreamhost API to add a record of recordType, eg "A", "TXT", or "CNAME", with value to domain and any errors.
+func AddRecord(domain string, recordType string, value string, apiKey string) (string, error) {
+	command := map[string]string{"cmd": "dns-add_record", "record": domain, "type": recordType, "value": value}
+	return submitRecordCommand(command, apiKey)
+}
+
+// DeleteRecord returns the JSON "result" field after using the Dreamhost API to delete the record of recordType with value from domain and any errors.
+func DeleteRecord(domain string, recordType string, value string, apiKey string) (string, error) {
+	command := map[string]string{"cmd": "dns-remove_record", "record": domain, "type": recordType, "value": value}
+	return submitRecordCommand(command, apiKey)
+}
+
+// submitRecordCommand returns the JSON "result" field after submitting an add or remove record command and any errors.
+// If the API reports an error, it is returned as a DreamhostAPIError.
+func submitRecordCommand(command map[string]string, apiKey string) (string, error) {
 	response, err := submitDreamhostCommand(command, apiKey)
 	if err != nil {
 		return "", err
@@ -99,22 +111,21 @@ func AddDNSRecord(domain string, newIPAddress string, apiKey string) (string, er
 	return result.Result, err
 }
 
+// addDNSRecord returns the JSON "result" field after using the Dreamhost API to add an IP address to a domain in dreamhost and any errors.
+// It adds an A record; use AddRecord for other types.
+func AddDNSRecord(domain string, newIPAddress string, apiKey string) (string, error) {
+	return AddRecord(domain, "A", newIPAddress, apiKey)
+}
+
 // deleteDNSRecord returns the JSON "result" field after using the Dreamhost API to delete an IP address from a domain in dreamhost and any errors.
+// It deletes an A record; use DeleteRecord for other types.
+// An error the API reports is only shown by the result being "error", with a nil error, as it always has been; DeleteRecord returns it as a DreamhostAPIError.
 func DeleteDNSRecord(domain string, newIPAddress string, apiKey string) (string, error) {
-	command := map[string]string{"cmd": "dns-remove_record", "record": domain, "type": "A", "value": newIPAddress}
-	response, err := submitDreamhostCommand(command, apiKey)
-	if err != nil {
-		return "", err
-	}
-	var result commandResult
-	jsonErr := json.Unmarshal([]byte(response), &result)
-	if jsonErr != nil {
-		return "", jsonErr
-	}
-	if result.Result == "error" {
-		err = DreamhostAPIError(result.Data)
+	result, err := DeleteRecord(domain, "A", newIPAddress, apiKey)
+	if _, ok := err.(DreamhostAPIError); ok {
+		return result, nil
 	}
-	return result.Result, jsonErr
+	return result, err
 }
 
 // updateDNSRecord returns the JSON "result" field after using the Dreamhost API to first add the new IP address and, if successful, deleting the old one.
@@ -0,0 +1,53 @@
+package dreamhostapi
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// acmeChallengeLabel is the label ACME DNS-01 validation places its TXT records under.
+const acmeChallengeLabel = "_acme-challenge"
+
+// StaleACMEChallenges returns the _acme-challenge TXT records that are older than maxAge at now.
+// Dreamhost does not record when a record was created, so a record's age comes from the CreatedKey metadata in its comment.
+// Challenge records without that metadata are considered stale, since a challenge is only needed for the few minutes validation takes.
+func (records DnsRecords) StaleACMEChallenges(maxAge time.Duration, now time.Time) DnsRecords {
+	stale := DnsRecords{Result: records.Result}
+	for _, record := range records.Data {
+		if record.ZoneType != "TXT" {
+			continue
+		}
+		name := strings.ToLower(record.Record)
+		if name != acmeChallengeLabel && !strings.HasPrefix(name, acmeChallengeLabel+".") {
+			continue
+		}
+		if created, ok := record.Created(); ok && now.Sub(created) < maxAge {
+			continue
+		}
+		stale.Data = append(stale.Data, record)
+	}
+	return stale
+}
+
+// CleanACMEChallenges deletes the stale _acme-challenge TXT records across every zone on the account and returns them.
+// With dryRun set, nothing is deleted; each record that would be removed is logged and returned instead.
+func (c *Client) CleanACMEChallenges(ctx context.Context, maxAge time.Duration, dryRun bool) ([]DnsRecord, error) {
+	records, err := c.dnsRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stale := records.StaleACMEChallenges(maxAge, time.Now()).Data
+	if dryRun {
+		for _, record := range stale {
+			c.logger.Printf("Dry run: would remove TXT record %s with value %s\n", record.Record, record.Value)
+		}
+		return stale, nil
+	}
+	return c.removeRecords(ctx, stale)
+}
+
+// CleanACMEChallenges is a shortcut for NewClient(apiKey).CleanACMEChallenges(context.Background(), maxAge, dryRun).
+func CleanACMEChallenges(apiKey string, maxAge time.Duration, dryRun bool) ([]DnsRecord, error) {
+	return NewClient(apiKey).CleanACMEChallenges(context.Background(), maxAge, dryRun)
+}
@@ -0,0 +1,113 @@
+package dreamhostapi
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// StopRetrying is returned by a BackoffStrategy to give up instead of waiting.
+const StopRetrying time.Duration = -1
+
+// ErrRateLimited is returned when the API keeps rate limiting requests and the backoff strategy has given up.
+var ErrRateLimited = DreamhostAPIError("rate limit hit and retries exhausted")
+
+// A BackoffStrategy decides how long to wait before retrying a request that the Dreamhost API rate limited.
+type BackoffStrategy interface {
+	// Backoff returns how long to wait before retry number attempt, starting at 1, or StopRetrying to give up.
+	Backoff(attempt int) time.Duration
+}
+
+// RateLimitBackoff is the strategy new Clients use when the Dreamhost API answers with HTTP 429.
+// The default waits 10 minutes between tries and never gives up.
+var RateLimitBackoff BackoffStrategy = ConstantBackoff{Delay: 600 * time.Second}
+
+// ConstantBackoff waits the same Delay before every retry. It suits cron jobs that can afford to wait.
+type ConstantBackoff struct {
+	Delay      time.Duration
+	MaxRetries int // give up after this many retries, 0 means retry forever
+}
+
+func (b ConstantBackoff) Backoff(attempt int) time.Duration {
+	if b.MaxRetries > 0 && attempt > b.MaxRetries {
+		return StopRetrying
+	}
+	return b.Delay
+}
+
+// ExponentialBackoff doubles the wait after every retry, starting at Initial and capped at Max. It suits long running daemons.
+type ExponentialBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration // 0 means no cap
+	MaxRetries int           // give up after this many retries, 0 means retry forever
+}
+
+func (b ExponentialBackoff) Backoff(attempt int) time.Duration {
+	if b.MaxRetries > 0 && attempt > b.MaxRetries {
+		return StopRetrying
+	}
+	delay := b.Initial
+	for i := 1; i < attempt; i++ {
+		if delay > math.MaxInt64/2 {
+			return math.MaxInt64 // the longest Duration there is, since doubling again would overflow
+		}
+		delay *= 2
+		if b.Max > 0 && delay >= b.Max {
+			return b.Max
+		}
+	}
+	return delay
+}
+
+// DecorrelatedJitterBackoff waits a random time between Base and three times the previous wait, capped at Max.
+// The randomness keeps many clients that were rate limited together from retrying in lockstep.
+// Use it through a pointer, since it remembers the previous wait.
+type DecorrelatedJitterBackoff struct {
+	Base       time.Duration
+	Max        time.Duration
+	MaxRetries int // give up after this many retries, 0 means retry forever
+
+	mu       sync.Mutex
+	previous time.Duration
+}
+
+func (b *DecorrelatedJitterBackoff) Backoff(attempt int) time.Duration {
+	if b.MaxRetries > 0 && attempt > b.MaxRetries {
+		return StopRetrying
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if attempt <= 1 || b.previous < b.Base {
+		b.previous = b.Base
+	}
+	delay := b.Base
+	if spread := b.previous*3 - b.Base; spread > 0 {
+		delay += time.Duration(rand.Int63n(int64(spread)))
+	}
+	if b.Max > 0 && delay > b.Max {
+		delay = b.Max
+	}
+	b.previous = delay
+	return delay
+}
+
+// sleep waits for d, returning early with ctx.Err() if ctx is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// NoBackoff gives up as soon as the API rate limits a request. It suits interactive programs.
+type NoBackoff struct{}
+
+func (NoBackoff) Backoff(int) time.Duration {
+	return StopRetrying
+}
@@ -0,0 +1,153 @@
+package dreamhostapi
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// A Client talks to the Dreamhost API with one API key and one set of configuration.
+// Create it with NewClient; the zero value is not usable.
+type Client struct {
+	apiKey       string
+	httpClient   *http.Client
+	baseURL      string
+	logger       *log.Logger
+	backoff      BackoffStrategy
+	decoder      Decoder
+	checkZones   bool
+	usePOST      bool
+	keyProvider  KeyProvider
+	checkScopes  bool
+	zoneCacheTTL time.Duration
+
+	zoneCacheMu sync.Mutex
+	zoneCache   hostedZones
+
+	scopeCacheMu sync.Mutex
+	scopeCache   keyScope
+}
+
+// DefaultHTTPClient is the HTTP client new Clients and WebGet start with.
+// Unlike http.DefaultClient it has a timeout, so a hung connection can't block forever.
+// Replace it to change the HTTP client the package-level functions use.
+var DefaultHTTPClient = &http.Client{Timeout: 60 * time.Second}
+
+// DefaultBaseURL is the public Dreamhost API endpoint.
+const DefaultBaseURL = "https://api.dreamhost.com/"
+
+// BaseURL is the API endpoint new Clients start with.
+// Replace it to point the package-level functions at a test server, proxy, or staging endpoint.
+var BaseURL = DefaultBaseURL
+
+// An Option configures a Client.
+type Option func(*Client)
+
+// NewClient returns a Client that uses apiKey for every command.
+// Without options it behaves like the package-level functions: it uses DefaultHTTPClient, BaseURL,
+// the standard logger, RateLimitBackoff, JSONDecoder, CheckZoneBeforeAdd, and HostedZoneCacheTTL.
+func NewClient(apiKey string, options ...Option) *Client {
+	c := &Client{
+		apiKey:       apiKey,
+		httpClient:   DefaultHTTPClient,
+		baseURL:      BaseURL,
+		logger:       log.Default(),
+		backoff:      RateLimitBackoff,
+		decoder:      JSONDecoder,
+		checkZones:   CheckZoneBeforeAdd,
+		zoneCacheTTL: HostedZoneCacheTTL,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithHTTPClient makes the Client send its requests with httpClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport makes the Client send its requests through transport, eg to add a proxy or instrumentation.
+// The rest of the HTTP client's settings, such as its timeout, are kept.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
+
+// WithBaseURL makes the Client send its requests to baseURL instead of BaseURL,
+// eg a local test server, a corporate proxy, or a staging endpoint.
+// Any query parameters already in baseURL are kept alongside the command's.
+// An unparsable baseURL is reported by the first call that uses it.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithLogger makes the Client write failed responses and rate-limit pauses to logger.
+func WithLogger(logger *log.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithBackoff sets the retry policy the Client follows when the API rate limits a request.
+func WithBackoff(backoff BackoffStrategy) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+// WithDecoder sets the Decoder the Client unmarshals API responses with.
+func WithDecoder(decoder Decoder) Option {
+	return func(c *Client) {
+		c.decoder = decoder
+	}
+}
+
+// WithZoneCheck turns the hosted-zone pre-flight on adds on or off. See Client.CheckZoneHosted.
+func WithZoneCheck(enabled bool) Option {
+	return func(c *Client) {
+		c.checkZones = enabled
+	}
+}
+
+// WithScopeCheck turns the scope pre-flight on or off.
+// When it is on, a command that changes the account, such as dns-add_record, is only sent if api-list_accessible_cmds lists it for the API key;
+// otherwise an InsufficientScopeError is returned.
+func WithScopeCheck(enabled bool) Option {
+	return func(c *Client) {
+		c.checkScopes = enabled
+	}
+}
+
+// WithZoneCacheTTL sets how long the Client reuses the list of hosted domains for the hosted-zone pre-flight,
+// and the API key's scope for the scope pre-flight.
+func WithZoneCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.zoneCacheTTL = ttl
+	}
+}
+
+// WithPOST makes the Client send commands, including the API key, in a POST form body instead of the URL's query string,
+// keeping the key out of proxy and server access logs.
+// If the endpoint answers a POST with 405 Method Not Allowed, the command is sent again with GET.
+func WithPOST(enabled bool) Option {
+	return func(c *Client) {
+		c.usePOST = enabled
+	}
+}
+
+// WithKeyProvider makes the Client ask provider for the API key before every command instead of using the key it was created with.
+func WithKeyProvider(provider KeyProvider) Option {
+	return func(c *Client) {
+		c.keyProvider = provider
+	}
+}
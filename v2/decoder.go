@@ -0,0 +1,24 @@
+package dreamhostapi
+
+import "encoding/json"
+
+// A Decoder unmarshals a response body from the Dreamhost API into v.
+type Decoder interface {
+	Decode(data []byte, v any) error
+}
+
+// DecoderFunc adapts a function with the signature of json.Unmarshal to a Decoder,
+// so a faster JSON library can be plugged in with DecoderFunc(otherjson.Unmarshal).
+type DecoderFunc func(data []byte, v any) error
+
+func (f DecoderFunc) Decode(data []byte, v any) error {
+	return f(data, v)
+}
+
+// JSONDecoder is the Decoder new Clients start with. It defaults to encoding/json.
+var JSONDecoder Decoder = DecoderFunc(json.Unmarshal)
+
+// decode unmarshals a response body from the Dreamhost API with the Client's Decoder.
+func (c *Client) decode(response string, v any) error {
+	return c.decoder.Decode([]byte(response), v)
+}
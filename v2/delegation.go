@@ -0,0 +1,100 @@
+package dreamhostapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DelegateSubdomain hands name over to another DNS provider by making nameservers its complete set of NS records.
+// Every nameserver must be a valid hostname outside the delegated subdomain, since Dreamhost can't serve glue records for it.
+// Missing NS records are added first; if any add fails, the ones added by this call are removed again and the error is returned.
+// Once all are in place, any other NS records for name are removed.
+func (c *Client) DelegateSubdomain(ctx context.Context, name string, nameservers []string) error {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if !validHostname(name) {
+		return fmt.Errorf("cannot delegate %q: not a valid hostname", name)
+	}
+	if len(nameservers) == 0 {
+		return errors.New("cannot delegate " + name + ": no nameservers given")
+	}
+	wanted := make(map[string]bool)
+	var ordered []string
+	for _, nameserver := range nameservers {
+		nameserver = strings.ToLower(strings.TrimSuffix(nameserver, "."))
+		if !validHostname(nameserver) {
+			return fmt.Errorf("cannot delegate %s: nameserver %q is not a valid hostname", name, nameserver)
+		}
+		if nameserver == name || strings.HasSuffix(nameserver, "."+name) {
+			return fmt.Errorf("cannot delegate %s: nameserver %s is inside the delegated subdomain and would need glue records", name, nameserver)
+		}
+		if !wanted[nameserver] {
+			wanted[nameserver] = true
+			ordered = append(ordered, nameserver)
+		}
+	}
+	records, err := c.dnsRecords(ctx)
+	if err != nil {
+		return err
+	}
+	for _, record := range records.Data {
+		if strings.EqualFold(record.Zone, name) {
+			return fmt.Errorf("cannot delegate %s: it is the apex of a zone hosted on this account", name)
+		}
+	}
+	current := records.nameservers(name)
+	var added []string
+	for _, nameserver := range ordered {
+		if records.contains(name, "NS", nameserver) {
+			continue
+		}
+		_, err := c.AddRecord(ctx, name, nameserver, WithType("NS"))
+		if err != nil {
+			for _, undo := range added {
+				c.RemoveRecord(ctx, name, undo, WithType("NS"))
+			}
+			return fmt.Errorf("delegating %s to %s: %w", name, nameserver, err)
+		}
+		added = append(added, nameserver)
+	}
+	var extraneous []DnsRecord
+	for _, record := range current {
+		if !wanted[strings.ToLower(strings.TrimSuffix(record.Value, "."))] {
+			extraneous = append(extraneous, record)
+		}
+	}
+	_, err = c.removeRecords(ctx, extraneous)
+	return err
+}
+
+// DelegateSubdomain is a shortcut for NewClient(apiKey).DelegateSubdomain(context.Background(), name, nameservers).
+func DelegateSubdomain(name string, nameservers []string, apiKey string) error {
+	return NewClient(apiKey).DelegateSubdomain(context.Background(), name, nameservers)
+}
+
+// RemoveDelegation removes every NS record for name, handing the subdomain back to the parent zone.
+func (c *Client) RemoveDelegation(ctx context.Context, name string) error {
+	records, err := c.dnsRecords(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = c.removeRecords(ctx, records.nameservers(strings.TrimSuffix(name, ".")))
+	return err
+}
+
+// RemoveDelegation is a shortcut for NewClient(apiKey).RemoveDelegation(context.Background(), name).
+func RemoveDelegation(name string, apiKey string) error {
+	return NewClient(apiKey).RemoveDelegation(context.Background(), name)
+}
+
+// nameservers returns the NS records for name.
+func (records DnsRecords) nameservers(name string) []DnsRecord {
+	var found []DnsRecord
+	for _, record := range records.Data {
+		if record.ZoneType == "NS" && strings.EqualFold(record.Record, name) {
+			found = append(found, record)
+		}
+	}
+	return found
+}
@@ -0,0 +1,178 @@
+package dreamhostapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrZoneNotHosted is returned when a record targets a zone that is not hosted on the account.
+var ErrZoneNotHosted = DreamhostAPIError("zone is not hosted on this account")
+
+// CheckZoneBeforeAdd is whether new Clients run the hosted-zone pre-flight before adding a record.
+// When true, adding a record first verifies that its zone is hosted on the account and fails with a ZoneNotHostedError if it isn't.
+var CheckZoneBeforeAdd = false
+
+// HostedZoneCacheTTL is how long new Clients reuse the list of hosted domains in CheckZoneHosted before fetching it again.
+var HostedZoneCacheTTL = 10 * time.Minute
+
+// Domains holds an array of Domain structs returned by the Dreamhost API
+type Domains struct {
+	Data   []Domain `json:"data"`
+	Result string   `json:"result"`
+}
+
+// Domain is a domain hosted on Dreamhost
+type Domain struct {
+	Domain      string // the domain name
+	Type        string // how the domain is hosted, eg http, mirror, redirect, or dns
+	Home        string // the web server hosting the domain
+	User        string // the user the domain belongs to
+	Path        string // the path of the web directory
+	HostingType string `json:"hosting_type"` // the hosting type, eg full or dns-only
+	AccountId   string `json:"account_id"`   // the account associated with this domain
+}
+
+// A ZoneNotHostedError reports a record whose zone was not found in the account's hosted domains.
+type ZoneNotHostedError struct {
+	Record     string // the record that was checked
+	Suggestion string // the closest matching hosted domain, if any
+}
+
+func (e *ZoneNotHostedError) Error() string {
+	if e.Suggestion == "" {
+		return fmt.Sprintf("%s: %s", ErrZoneNotHosted, e.Record)
+	}
+	return fmt.Sprintf("%s: %s (did you mean %s?)", ErrZoneNotHosted, e.Record, e.Suggestion)
+}
+
+func (e *ZoneNotHostedError) Unwrap() error {
+	return ErrZoneNotHosted
+}
+
+// ListDomains returns a Domains struct containing all of the domains hosted on the account and any errors.
+func (c *Client) ListDomains(ctx context.Context) (Domains, error) {
+	command := map[string]string{"cmd": "domain-list_domains"}
+	response, err := submitCommand[[]Domain](ctx, c, command)
+	if err != nil {
+		return Domains{}, err
+	}
+	return Domains{Data: response.Data, Result: response.Result}, nil
+}
+
+// ListDomains is a shortcut for NewClient(apiKey).ListDomains(context.Background()).
+func ListDomains(apiKey string) (Domains, error) {
+	return NewClient(apiKey).ListDomains(context.Background())
+}
+
+// hostedZones is the Client's cache of the domains hosted on the account.
+type hostedZones struct {
+	zones   []string
+	fetched time.Time
+}
+
+// hostedDomains returns the names of the domains hosted on the account, using the cache when it is fresh.
+func (c *Client) hostedDomains(ctx context.Context) ([]string, error) {
+	c.zoneCacheMu.Lock()
+	cached := c.zoneCache
+	c.zoneCacheMu.Unlock()
+	if cached.zones != nil && time.Since(cached.fetched) < c.zoneCacheTTL {
+		return cached.zones, nil
+	}
+	domains, err := c.ListDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zones := make([]string, 0, len(domains.Data))
+	for _, domain := range domains.Data {
+		zones = append(zones, strings.ToLower(domain.Domain))
+	}
+	c.zoneCacheMu.Lock()
+	c.zoneCache = hostedZones{zones: zones, fetched: time.Now()}
+	c.zoneCacheMu.Unlock()
+	return zones, nil
+}
+
+// CheckZoneHosted returns the hosted zone that record belongs to, or a ZoneNotHostedError naming the closest hosted domain.
+// The record belongs to a zone when it is equal to, or a subdomain of, one of the account's domains.
+// The domain list is cached by the Client; see WithZoneCacheTTL.
+func (c *Client) CheckZoneHosted(ctx context.Context, record string) (string, error) {
+	zones, err := c.hostedDomains(ctx)
+	if err != nil {
+		return "", err
+	}
+	name := strings.ToLower(strings.TrimSuffix(record, "."))
+	var match string
+	for _, zone := range zones {
+		if (name == zone || strings.HasSuffix(name, "."+zone)) && len(zone) > len(match) {
+			match = zone
+		}
+	}
+	if match != "" {
+		return match, nil
+	}
+	return "", &ZoneNotHostedError{Record: record, Suggestion: closestZone(name, zones)}
+}
+
+// CheckZoneHosted is a shortcut for NewClient(apiKey).CheckZoneHosted(context.Background(), record).
+// Since it makes a new Client every time, the domain list is fetched on every call.
+func CheckZoneHosted(record string, apiKey string) (string, error) {
+	return NewClient(apiKey).CheckZoneHosted(context.Background(), record)
+}
+
+// closestZone returns the hosted zone with the smallest edit distance to any suffix of name.
+func closestZone(name string, zones []string) string {
+	labels := strings.Split(name, ".")
+	best, bestDistance := "", -1
+	for i := range labels {
+		candidate := strings.Join(labels[i:], ".")
+		for _, zone := range zones {
+			distance := editDistance(candidate, zone)
+			if bestDistance == -1 || distance < bestDistance {
+				best, bestDistance = zone, distance
+			}
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// validHostname reports whether name is a syntactically valid DNS hostname, with or without a trailing dot.
+func validHostname(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -2,13 +2,15 @@
 package dreamhostapi
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"net/url"
-	"time"
+	"strings"
 )
 
 type DreamhostAPIError string
@@ -39,8 +41,33 @@ func (r DnsRecord) String() string {
 }
 
 // webGet returns the body as a string, an int representing the HTTP status code, and any errors.
+// It uses DefaultHTTPClient.
 func WebGet(url string) (string, int, error) {
-	response, err := http.Get(url)
+	return webGet(context.Background(), DefaultHTTPClient, log.Default(), url)
+}
+
+// webGet does the work of WebGet with the given HTTP client and logger, giving up when ctx is done.
+func webGet(ctx context.Context, httpClient *http.Client, logger *log.Logger, url string) (string, int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "Error accessing URL", 0, err
+	}
+	return doRequest(httpClient, logger, request)
+}
+
+// webPost is like webGet, but POSTs form to target as the request body.
+func webPost(ctx context.Context, httpClient *http.Client, logger *log.Logger, target string, form url.Values) (string, int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "Error accessing URL", 0, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doRequest(httpClient, logger, request)
+}
+
+// doRequest sends request and returns the body as a string, the HTTP status code, and any errors.
+func doRequest(httpClient *http.Client, logger *log.Logger, request *http.Request) (string, int, error) {
+	response, err := httpClient.Do(request)
 	if err != nil {
 		return "Error accessing URL", 0, err
 	}
@@ -48,7 +75,7 @@ func WebGet(url string) (string, int, error) {
 	response.Body.Close()
 	if response.StatusCode > 299 {
 		statusCodeString := fmt.Sprintf("Response failed with status code: %d and \nbody: %s\n", response.StatusCode, result)
-		log.Println(statusCodeString)
+		logger.Println(statusCodeString)
 	}
 	if err != nil {
 		return "Error reading response", 0, err
@@ -56,104 +83,250 @@ func WebGet(url string) (string, int, error) {
 	return string(result), response.StatusCode, err
 }
 
-// A commandResult holds the JSON result from adding or removing a record using the Dreamhost API.
-type commandResult struct {
-	Data   string `json:"data"`   // A string representing what happened, eg "record_added".
-	Result string `json:"result"` // A string representing whether the API was successfully.
+// redactKey replaces the value of the key query parameter in any URL carried by err, so the API key doesn't end up in logs or error messages.
+func redactKey(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
+	}
+	return err
+}
+
+// redactURL returns rawURL with the value of its key query parameter replaced by REDACTED.
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := parsed.Query()
+	if !query.Has("key") {
+		return rawURL
+	}
+	query.Set("key", "REDACTED")
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
 }
 
+// A CommandResult holds the JSON result from adding or removing a record using the Dreamhost API.
+// Its Data is a string representing what happened, eg "record_added". If Result is "error", Data holds the error code for UpdateZoneFile,
+// and is empty for AddRecord and RemoveRecord, which return the code in a DreamhostAPIError instead.
+type CommandResult = Response[string]
+
 // submitDreamhostCommand returns the response from the Dreamhost API as JSON as well as any errors.
 // In the case of any errors (eg web access) it returns an empty string.
+// The API key is redacted from any URL in the returned error.
+// Commands in the registry are checked locally first and a ValidationError is returned without calling the API if they are malformed.
+// If the scope pre-flight is on, commands that change the account and that the API key may not run fail with an InsufficientScopeError.
+// When the API rate limits the request, it waits and retries as the Client's BackoffStrategy decides, returning ErrRateLimited if that gives up.
+// Cancelling ctx aborts both the request and any wait, returning ctx.Err().
 // The command map is essentially a map in which the keys correspond to the items that can be edited by the API.
 // As of now, all [Dreamhost DNS commands] are implemented.
 //
 // [Dreamhost DNS commands]: https://help.dreamhost.com/hc/en-us/articles/217555707-DNS-API-commands
-func submitDreamhostCommand(command map[string]string, apiKey string) (string, error) {
-	var dreamhostResponse string
-	apiURLBase := "https://api.dreamhost.com/?"
-	queryParameters := url.Values{}
-	queryParameters.Set("key", apiKey)
+func (c *Client) submitDreamhostCommand(ctx context.Context, command map[string]string) (string, error) {
+	if err := checkCommand(command); err != nil {
+		return "", err
+	}
+	if c.checkScopes {
+		if err := c.checkScope(ctx, command["cmd"]); err != nil {
+			return "", err
+		}
+	}
+	fullURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid API base URL: %w", err)
+	}
+	apiKey, err := c.key(ctx)
+	if err != nil {
+		return "", err
+	}
+	parameters := url.Values{}
+	parameters.Set("key", apiKey)
 	for key, value := range command {
-		queryParameters.Add(key, value)
+		parameters.Add(key, value)
 	}
-	queryParameters.Add("format", "json")
-	fullURL := apiURLBase + queryParameters.Encode()
-	dreamhostResponse, statusCode, err := WebGet(fullURL)
-	if err != nil { // there was an error at the web level.
-		return dreamhostResponse, err
+	parameters.Add("format", "json")
+	for attempt := 1; ; attempt++ {
+		dreamhostResponse, statusCode, err := c.send(ctx, fullURL, parameters)
+		if err != nil { // there was an error at the web level.
+			return dreamhostResponse, redactKey(err)
+		}
+		if statusCode != 429 {
+			return dreamhostResponse, err
+		}
+		delay := c.backoff.Backoff(attempt)
+		if delay < 0 {
+			return dreamhostResponse, ErrRateLimited
+		}
+		c.logger.Printf("Rate limit hit. Pausing execution for %s.\n", delay)
+		if err := sleep(ctx, delay); err != nil {
+			return dreamhostResponse, err
+		}
 	}
-	if statusCode == 429 {
-		fmt.Println("Rate limit hit. Pausing execution for 10 minutes.")
-		time.Sleep(600 * time.Second)
-		dreamhostResponse, err = submitDreamhostCommand(command, apiKey)
+}
+
+// send sends parameters to endpoint, in a POST form body if the Client is set to use POST and otherwise in the query string alongside any parameters endpoint already has.
+// If the endpoint refuses the POST with 405 Method Not Allowed, the command is sent again with GET.
+func (c *Client) send(ctx context.Context, endpoint *url.URL, parameters url.Values) (string, int, error) {
+	if c.usePOST {
+		dreamhostResponse, statusCode, err := webPost(ctx, c.httpClient, c.logger, endpoint.String(), parameters)
+		if err != nil || statusCode != http.StatusMethodNotAllowed {
+			return dreamhostResponse, statusCode, err
+		}
+		c.logger.Println("POST not allowed by the API endpoint, falling back to GET.")
 	}
-	return dreamhostResponse, err
+	fullURL := *endpoint
+	query := fullURL.Query()
+	for key, values := range parameters {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	fullURL.RawQuery = query.Encode()
+	return webGet(ctx, c.httpClient, c.logger, fullURL.String())
 }
 
-// getDNSRecords returns a DnsRecords struct containing all of the DNS records that correspond to this apiKey and any errors.
-// It returns an empty struct in the case of any errors in the web-layer, JSON demarshalling, or API non-success result.
-func GetDNSRecords(apiKey string) (DnsRecords, error) {
-	var emptyRecords DnsRecords
-	command := map[string]string{"cmd": "dns-list_records"}
-	cmdResult, err := submitDreamhostCommand(command, apiKey)
-	if err != nil {
-		return emptyRecords, err // will already be the empty record
+// SubmitCommand runs any Dreamhost API command, including ones this package doesn't wrap yet, and returns its decoded envelope.
+// The key, cmd, and format parameters are filled in; params holds the rest.
+// If the API reports an error, the envelope is returned along with a DreamhostAPIError holding the error code from its data field.
+func (c *Client) SubmitCommand(ctx context.Context, cmd string, params map[string]string) (Envelope, error) {
+	command := map[string]string{"cmd": cmd}
+	for key, value := range params {
+		if key != "cmd" {
+			command[key] = value
+		}
 	}
-	var dnsRecordList DnsRecords
-	err = json.Unmarshal([]byte(cmdResult), &dnsRecordList)
-	if err != nil {
-		return emptyRecords, err // there was an error at the JSON unmarshalling level
+	return submitCommand[json.RawMessage](ctx, c, command)
+}
+
+// SubmitCommand is a shortcut for NewClient(apiKey).SubmitCommand(context.Background(), cmd, params).
+func SubmitCommand(cmd string, params map[string]string, apiKey string) (Envelope, error) {
+	return NewClient(apiKey).SubmitCommand(context.Background(), cmd, params)
+}
+
+// GetDNSRecords returns a DnsRecords struct containing all of the DNS records on the account and any errors.
+// It returns an empty struct in the case of any errors in the web-layer, JSON demarshalling, or API non-success result,
+// and in the last case the error is nil, as it always has been; check Result, or use a method such as EnsureRecord that reports it.
+func (c *Client) GetDNSRecords(ctx context.Context) (DnsRecords, error) {
+	records, err := c.dnsRecords(ctx)
+	if _, ok := err.(DreamhostAPIError); ok && records.Result != "" && records.Result != "success" {
+		return DnsRecords{}, nil
 	}
-	if dnsRecordList.Result != "success" { // we hit the API successfully, but did not get back JSON successfully. eg: bad APIKey.
-		return emptyRecords, err
+	return records, err
+}
+
+// dnsRecords is GetDNSRecords, but returns a non-success result from the API as a DreamhostAPIError holding its error code,
+// with the Result of the returned DnsRecords set.
+func (c *Client) dnsRecords(ctx context.Context) (DnsRecords, error) {
+	command := map[string]string{"cmd": "dns-list_records"}
+	response, err := submitCommand[[]DnsRecord](ctx, c, command)
+	if err != nil {
+		return DnsRecords{Result: response.Result}, err
 	}
-	return dnsRecordList, err
+	return DnsRecords{Data: response.Data, Result: response.Result}, nil
 }
 
-// UpdateZoneFile returns a commandResult after using the Dreamhost API to either add or delete an IP address from a domain in Dreamhost and any errors.
+// getDNSRecords returns a DnsRecords struct containing all of the DNS records that correspond to this apiKey and any errors.
+// It is a shortcut for NewClient(apiKey).GetDNSRecords(context.Background()).
+func GetDNSRecords(apiKey string) (DnsRecords, error) {
+	return NewClient(apiKey).GetDNSRecords(context.Background())
+}
+
+// UpdateZoneFile returns a CommandResult after using the Dreamhost API to either add or delete an IP address from a domain in Dreamhost and any errors.
 // In the case of a success, it should only contain one record in the slice.
-// It returns an empty struct in the case of any errors in the web-layer, JSON demarshalling, or API non-success result.
+// It returns an empty struct in the case of any errors in the web-layer or JSON demarshalling.
+// If the API does not succeed, the result holds its error code, and the error is nil as it always has been;
+// AddRecord and RemoveRecord return it as a DreamhostAPIError instead.
+// If the hosted-zone pre-flight is on, adding a record to a zone that isn't hosted on the account returns a ZoneNotHostedError without calling dns-add_record.
 // Currently implemented commands for the command parameter are:
 //   - "add" to add a value (typically IP address) to a record (typically a domain).
 //   - "del" to remove a value (typically IP address) from a record (typically a domain).
-func UpdateZoneFile(command string, domain string, IPAddress string, apiKey string, comment string) (commandResult, error) {
-	var updateResult commandResult
+//
+// The record is an A record unless WithType says otherwise.
+func (c *Client) UpdateZoneFile(ctx context.Context, command string, domain string, IPAddress string, opts ...RecordOption) (CommandResult, error) {
+	commandOptions, err := c.recordCommand(ctx, command, domain, IPAddress, opts...)
+	if err != nil {
+		return CommandResult{}, err
+	}
+	body, err := c.submitDreamhostCommand(ctx, commandOptions)
+	if err != nil {
+		return CommandResult{}, err
+	}
+	return decodeResult[string](c, body)
+}
+
+// UpdateZoneFile is a shortcut for NewClient(apiKey).UpdateZoneFile(context.Background(), command, domain, IPAddress, WithComment(comment)).
+func UpdateZoneFile(command string, domain string, IPAddress string, apiKey string, comment string) (CommandResult, error) {
+	return NewClient(apiKey).UpdateZoneFile(context.Background(), command, domain, IPAddress, WithComment(comment))
+}
+
+// AddRecord returns a CommandResult after using the Dreamhost API to add value to record and any errors.
+// The record is an A record unless WithType says otherwise.
+// If the API does not succeed, the result's Result is "error" and its Data is empty; the error code is only in the returned DreamhostAPIError.
+func (c *Client) AddRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	return c.changeRecord(ctx, "add", record, value, opts...)
+}
+
+// RemoveRecord returns a CommandResult after using the Dreamhost API to remove value from record and any errors.
+// The record is an A record unless WithType says otherwise.
+// If the API does not succeed, the result's Result is "error" and its Data is empty; the error code is only in the returned DreamhostAPIError.
+func (c *Client) RemoveRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	return c.changeRecord(ctx, "del", record, value, opts...)
+}
+
+// changeRecord does the work of AddRecord and RemoveRecord. command is "add" or "del".
+func (c *Client) changeRecord(ctx context.Context, command string, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	commandOptions, err := c.recordCommand(ctx, command, record, value, opts...)
+	if err != nil {
+		return CommandResult{}, err
+	}
+	return submitCommand[string](ctx, c, commandOptions)
+}
+
+// recordCommand returns the dns-add_record or dns-remove_record command for command "add" or "del",
+// after running the pre-flights the Client has turned on.
+func (c *Client) recordCommand(ctx context.Context, command string, record string, value string, opts ...RecordOption) (map[string]string, error) {
+	options := newRecordOptions(opts)
 	var commandOptions map[string]string
 	switch command {
 	case "add":
-		commandOptions = map[string]string{"cmd": "dns-add_record", "record": domain, "type": "A", "value": IPAddress, "comment": comment}
+		commandOptions = map[string]string{"cmd": "dns-add_record", "record": record, "type": options.recordType, "value": value}
 	case "del":
-		commandOptions = map[string]string{"cmd": "dns-remove_record", "record": domain, "type": "A", "value": IPAddress, "comment": comment}
+		commandOptions = map[string]string{"cmd": "dns-remove_record", "record": record, "type": options.recordType, "value": value}
+	default:
+		return nil, fmt.Errorf("unknown zone file command %q", command)
 	}
-	if comment == "" {
-		delete(commandOptions, "comment")
+	if options.comment != "" {
+		commandOptions["comment"] = options.comment
 	}
-	response, err := submitDreamhostCommand(commandOptions, apiKey)
-	if err != nil {
-		return updateResult, err
+	if options.account != "" {
+		commandOptions["account"] = options.account
 	}
-	err = json.Unmarshal([]byte(response), &updateResult)
-	if err != nil {
-		return updateResult, err // there was an error at the JSON unmarshalling level
+	if command == "add" && c.checkZones {
+		if _, err := c.CheckZoneHosted(ctx, record); err != nil {
+			return nil, err
+		}
 	}
-
-	return updateResult, err
+	return commandOptions, nil
 }
 
-// updateDNSRecord returns a commandResult after using the Dreamhost API to first add the new IP address and, if successful, deleting the old one.
+// UpdateDNSRecord returns a CommandResult after using the Dreamhost API to first add the new IP address and, if successful, deleting the old one.
 // If adding a record does not succeed, either through underlying error (web, JSON unmarshalling) or because the API was not successful, it will not continue to the deletion.
-func UpdateDNSRecord(domain string, currentIP string, newIPAddress string, apiKey string, comment string) (commandResult, commandResult, error) {
-	var empty commandResult
-	resultOfAdd, err := UpdateZoneFile("add", domain, newIPAddress, apiKey, comment)
-	if err != nil {
-		return empty, empty, err
-	}
-	if resultOfAdd.Result != "success" {
+// opts apply to both the add and the removal.
+func (c *Client) UpdateDNSRecord(ctx context.Context, domain string, currentIP string, newIPAddress string, opts ...RecordOption) (CommandResult, CommandResult, error) {
+	var empty CommandResult
+	resultOfAdd, err := c.UpdateZoneFile(ctx, "add", domain, newIPAddress, opts...)
+	if err != nil || resultOfAdd.Result != "success" {
 		return resultOfAdd, empty, err
 	}
-	resultOfDelete, err := UpdateZoneFile("del", domain, currentIP, apiKey, comment)
+	resultOfDelete, err := c.UpdateZoneFile(ctx, "del", domain, currentIP, opts...)
 	if err != nil {
 		return resultOfAdd, resultOfDelete, err
 	}
 	return resultOfAdd, resultOfDelete, err
 }
+
+// updateDNSRecord is a shortcut for NewClient(apiKey).UpdateDNSRecord(context.Background(), domain, currentIP, newIPAddress, WithComment(comment)).
+func UpdateDNSRecord(domain string, currentIP string, newIPAddress string, apiKey string, comment string) (CommandResult, CommandResult, error) {
+	return NewClient(apiKey).UpdateDNSRecord(context.Background(), domain, currentIP, newIPAddress, WithComment(comment))
+}
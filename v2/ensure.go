@@ -0,0 +1,78 @@
+package dreamhostapi
+
+import (
+	"context"
+	"strings"
+)
+
+// EnsureRecord adds a record of recordType with value to record unless an identical one already exists.
+// It reports whether a record was added. A non-success result from the API is returned as an error.
+func (c *Client) EnsureRecord(ctx context.Context, record string, recordType string, value string, comment string) (bool, error) {
+	records, err := c.dnsRecords(ctx)
+	if err != nil {
+		return false, err
+	}
+	if records.contains(record, recordType, value) {
+		return false, nil
+	}
+	_, err = c.AddRecord(ctx, record, value, WithType(recordType), WithComment(comment))
+	return err == nil, err
+}
+
+// EnsureRecord is a shortcut for NewClient(apiKey).EnsureRecord(context.Background(), record, recordType, value, comment).
+func EnsureRecord(record string, recordType string, value string, apiKey string, comment string) (bool, error) {
+	return NewClient(apiKey).EnsureRecord(context.Background(), record, recordType, value, comment)
+}
+
+// EnsureOnly makes values the exact set of recordType records for name and returns the values it added and removed.
+// Missing values are added before extraneous ones are deleted, so the name never goes without a record.
+// If an add fails, nothing is deleted and the error is returned.
+func (c *Client) EnsureOnly(ctx context.Context, name string, recordType string, values []string, comment string) ([]string, []string, error) {
+	records, err := c.dnsRecords(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	wanted := make(map[string]bool)
+	var added []string
+	for _, value := range values {
+		if wanted[value] {
+			continue
+		}
+		wanted[value] = true
+		if records.contains(name, recordType, value) {
+			continue
+		}
+		_, err := c.AddRecord(ctx, name, value, WithType(recordType), WithComment(comment))
+		if err != nil {
+			return added, nil, err
+		}
+		added = append(added, value)
+	}
+	var extraneous []DnsRecord
+	for _, record := range records.Data {
+		if strings.EqualFold(record.Record, name) && record.ZoneType == recordType && !wanted[record.Value] {
+			extraneous = append(extraneous, record)
+		}
+	}
+	deleted, err := c.removeRecords(ctx, extraneous)
+	removed := make([]string, 0, len(deleted))
+	for _, record := range deleted {
+		removed = append(removed, record.Value)
+	}
+	return added, removed, err
+}
+
+// EnsureOnly is a shortcut for NewClient(apiKey).EnsureOnly(context.Background(), name, recordType, values, comment).
+func EnsureOnly(name string, recordType string, values []string, apiKey string, comment string) ([]string, []string, error) {
+	return NewClient(apiKey).EnsureOnly(context.Background(), name, recordType, values, comment)
+}
+
+// contains reports whether there is a record with this name, type, and value.
+func (records DnsRecords) contains(record string, recordType string, value string) bool {
+	for _, existing := range records.Data {
+		if strings.EqualFold(existing.Record, record) && existing.ZoneType == recordType && existing.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,131 @@
+package dreamhostapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// A MultiClient manages DNS across several Dreamhost accounts, one Client per API key.
+// Listing fans out to every Client concurrently; adds and removes go to the Client whose account hosts the record's zone.
+type MultiClient struct {
+	clients []*Client
+}
+
+var _ DNSService = (*MultiClient)(nil)
+
+// A TaggedRecord is a DNS record along with the Client, and so the API key, it was listed with.
+type TaggedRecord struct {
+	DnsRecord
+	Source *Client
+}
+
+// NewMultiClient returns a MultiClient with a Client for each of apiKeys, all configured with options.
+func NewMultiClient(apiKeys []string, options ...Option) *MultiClient {
+	m := &MultiClient{}
+	for _, apiKey := range apiKeys {
+		m.clients = append(m.clients, NewClient(apiKey, options...))
+	}
+	return m
+}
+
+// NewMultiClientFrom returns a MultiClient over clients, for when the accounts need different configurations.
+func NewMultiClientFrom(clients ...*Client) *MultiClient {
+	return &MultiClient{clients: clients}
+}
+
+// Clients returns the Clients the MultiClient uses, in the order they were given.
+func (m *MultiClient) Clients() []*Client {
+	return append([]*Client(nil), m.clients...)
+}
+
+// GetTaggedRecords returns the DNS records of every account, each tagged with the Client it came from, and any errors.
+// The accounts are listed concurrently. If some fail, the records of the others are returned along with the joined errors.
+func (m *MultiClient) GetTaggedRecords(ctx context.Context) ([]TaggedRecord, error) {
+	results := make([]DnsRecords, len(m.clients))
+	errs := make([]error, len(m.clients))
+	var wg sync.WaitGroup
+	for i, c := range m.clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			results[i], errs[i] = c.dnsRecords(ctx)
+		}(i, c)
+	}
+	wg.Wait()
+	var tagged []TaggedRecord
+	for i, records := range results {
+		for _, record := range records.Data {
+			tagged = append(tagged, TaggedRecord{DnsRecord: record, Source: m.clients[i]})
+		}
+	}
+	return tagged, errors.Join(errs...)
+}
+
+// GetDNSRecords returns the DNS records of every account in one DnsRecords struct and any errors.
+// The AccountId of each record says which account it belongs to; use GetTaggedRecords to also learn the Client.
+func (m *MultiClient) GetDNSRecords(ctx context.Context) (DnsRecords, error) {
+	tagged, err := m.GetTaggedRecords(ctx)
+	records := DnsRecords{Result: "success"}
+	for _, record := range tagged {
+		records.Data = append(records.Data, record.DnsRecord)
+	}
+	if err != nil {
+		records.Result = "error"
+	}
+	return records, err
+}
+
+// ClientFor returns the Client whose account hosts the zone record belongs to.
+// If more than one account hosts a matching zone, the most specific zone wins.
+// If none does, the error is a ZoneNotHostedError naming the closest hosted domain across all accounts.
+func (m *MultiClient) ClientFor(ctx context.Context, record string) (*Client, error) {
+	name := strings.ToLower(strings.TrimSuffix(record, "."))
+	var match string
+	var owner *Client
+	var allZones []string
+	for _, c := range m.clients {
+		zones, err := c.hostedDomains(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, zone := range zones {
+			if (name == zone || strings.HasSuffix(name, "."+zone)) && len(zone) > len(match) {
+				match, owner = zone, c
+			}
+		}
+		allZones = append(allZones, zones...)
+	}
+	if owner == nil {
+		return nil, &ZoneNotHostedError{Record: record, Suggestion: closestZone(name, allZones)}
+	}
+	return owner, nil
+}
+
+// AddRecord adds value to record using the Client whose account hosts the record's zone.
+func (m *MultiClient) AddRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	c, err := m.ClientFor(ctx, record)
+	if err != nil {
+		return CommandResult{}, err
+	}
+	return c.AddRecord(ctx, record, value, opts...)
+}
+
+// RemoveRecord removes value from record using the Client whose account hosts the record's zone.
+func (m *MultiClient) RemoveRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	c, err := m.ClientFor(ctx, record)
+	if err != nil {
+		return CommandResult{}, err
+	}
+	return c.RemoveRecord(ctx, record, value, opts...)
+}
+
+// UpdateDNSRecord replaces currentIP with newIPAddress using the Client whose account hosts the domain's zone.
+func (m *MultiClient) UpdateDNSRecord(ctx context.Context, domain string, currentIP string, newIPAddress string, opts ...RecordOption) (CommandResult, CommandResult, error) {
+	c, err := m.ClientFor(ctx, domain)
+	if err != nil {
+		return CommandResult{}, CommandResult{}, err
+	}
+	return c.UpdateDNSRecord(ctx, domain, currentIP, newIPAddress, opts...)
+}
@@ -0,0 +1,107 @@
+package dreamhostapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// An MXRecord is the value of an MX record: a mail server and its priority.
+type MXRecord struct {
+	Priority int    // lower values are tried first
+	Target   string // the hostname of the mail server
+}
+
+// ParseMX parses an MX record value such as "10 mail1.example.com".
+func ParseMX(value string) (MXRecord, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return MXRecord{}, fmt.Errorf("MX value %q is not in the form \"priority target\"", value)
+	}
+	priority, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return MXRecord{}, fmt.Errorf("MX value %q has an invalid priority: %w", value, err)
+	}
+	mx := MXRecord{Priority: priority, Target: fields[1]}
+	return mx, mx.Validate()
+}
+
+// String returns the MX record value in the form Dreamhost expects, eg "10 mail1.example.com".
+func (mx MXRecord) String() string {
+	return fmt.Sprintf("%d %s", mx.Priority, mx.Target)
+}
+
+// Validate checks that the priority is in range and the target is a valid hostname.
+func (mx MXRecord) Validate() error {
+	if mx.Priority < 0 || mx.Priority > 65535 {
+		return fmt.Errorf("MX priority %d is out of range 0-65535", mx.Priority)
+	}
+	if !validHostname(mx.Target) {
+		return fmt.Errorf("MX target %q is not a valid hostname", mx.Target)
+	}
+	return nil
+}
+
+// MX returns the MX records for domain, sorted by priority.
+// Values that don't parse as MX records are skipped.
+func (records DnsRecords) MX(domain string) []MXRecord {
+	var mxs []MXRecord
+	for _, record := range records.Data {
+		if record.ZoneType != "MX" || !strings.EqualFold(record.Record, domain) {
+			continue
+		}
+		if mx, err := ParseMX(record.Value); err == nil {
+			mxs = append(mxs, mx)
+		}
+	}
+	sort.SliceStable(mxs, func(i, j int) bool { return mxs[i].Priority < mxs[j].Priority })
+	return mxs
+}
+
+// ListMX returns the MX records for domain, sorted by priority, and any errors.
+func (c *Client) ListMX(ctx context.Context, domain string) ([]MXRecord, error) {
+	records, err := c.dnsRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return records.MX(domain), nil
+}
+
+// ListMX is a shortcut for NewClient(apiKey).ListMX(context.Background(), domain).
+func ListMX(domain string, apiKey string) ([]MXRecord, error) {
+	return NewClient(apiKey).ListMX(context.Background(), domain)
+}
+
+// errMXNotAccepted is returned by AddMX and ReplaceMX, since the API rejects MX records.
+var errMXNotAccepted = &ValidationError{Command: "dns-add_record", Field: "type", Value: "MX", Reason: "is not accepted by the API; manage MX records in the Dreamhost panel"}
+
+// AddMX returns a ValidationError without sending anything, since the API doesn't accept MX records.
+//
+// Deprecated: Manage MX records in the Dreamhost panel.
+func (c *Client) AddMX(ctx context.Context, domain string, mx MXRecord, comment string) (CommandResult, error) {
+	return CommandResult{}, errMXNotAccepted
+}
+
+// AddMX is a shortcut for NewClient(apiKey).AddMX(context.Background(), domain, mx, comment).
+//
+// Deprecated: Manage MX records in the Dreamhost panel.
+func AddMX(domain string, mx MXRecord, apiKey string, comment string) (CommandResult, error) {
+	return NewClient(apiKey).AddMX(context.Background(), domain, mx, comment)
+}
+
+// ReplaceMX returns a ValidationError without sending anything, so it can't remove the existing MX records and then fail to add the new ones,
+// since the API doesn't accept MX records.
+//
+// Deprecated: Manage MX records in the Dreamhost panel.
+func (c *Client) ReplaceMX(ctx context.Context, domain string, mxs []MXRecord, comment string) error {
+	return errMXNotAccepted
+}
+
+// ReplaceMX is a shortcut for NewClient(apiKey).ReplaceMX(context.Background(), domain, mxs, comment).
+//
+// Deprecated: Manage MX records in the Dreamhost panel.
+func ReplaceMX(domain string, mxs []MXRecord, apiKey string, comment string) error {
+	return NewClient(apiKey).ReplaceMX(context.Background(), domain, mxs, comment)
+}
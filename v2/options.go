@@ -0,0 +1,42 @@
+package dreamhostapi
+
+// recordOptions holds the optional attributes of an add or remove command.
+type recordOptions struct {
+	recordType string // defaults to A
+	comment    string // left out of the command when empty
+	account    string // left out of the command when empty
+}
+
+// A RecordOption sets an optional attribute of a single add or remove command, eg its comment or record type.
+// New attributes get new options, so adding them doesn't change any method's signature.
+type RecordOption func(*recordOptions)
+
+// WithComment sets the comment stored with the record.
+func WithComment(comment string) RecordOption {
+	return func(o *recordOptions) {
+		o.comment = comment
+	}
+}
+
+// WithType sets the record type, eg "AAAA" or "TXT", instead of the default "A".
+func WithType(recordType string) RecordOption {
+	return func(o *recordOptions) {
+		o.recordType = recordType
+	}
+}
+
+// WithAccount runs the command against account, for API keys that can manage more than one account.
+func WithAccount(account string) RecordOption {
+	return func(o *recordOptions) {
+		o.account = account
+	}
+}
+
+// newRecordOptions applies opts to the defaults.
+func newRecordOptions(opts []RecordOption) recordOptions {
+	o := recordOptions{recordType: "A"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
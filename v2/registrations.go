@@ -0,0 +1,89 @@
+package dreamhostapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Registrations holds an array of Registration structs returned by the Dreamhost API
+type Registrations struct {
+	Data   []Registration `json:"data"`
+	Result string         `json:"result"`
+}
+
+// Registration is a domain registered through Dreamhost
+type Registration struct {
+	Domain    string // the registered domain
+	Expires   string // the date the registration expires, eg 2025-04-01
+	Created   string // the date the domain was registered
+	Modified  string // the date the registration was last changed
+	Autorenew string // whether the registration renews automatically, comes back as a string
+	Locked    string // whether the domain is locked against transfers, comes back as a string
+	Expired   string // whether the registration has already expired, comes back as a string
+	AccountId string `json:"account_id"` // the account associated with this registration
+}
+
+// ListRegistrations returns a Registrations struct containing all of the domains registered on the account and any errors.
+func (c *Client) ListRegistrations(ctx context.Context) (Registrations, error) {
+	command := map[string]string{"cmd": "domain-list_registrations"}
+	response, err := submitCommand[[]Registration](ctx, c, command)
+	if err != nil {
+		return Registrations{}, err
+	}
+	return Registrations{Data: response.Data, Result: response.Result}, nil
+}
+
+// ListRegistrations is a shortcut for NewClient(apiKey).ListRegistrations(context.Background()).
+func ListRegistrations(apiKey string) (Registrations, error) {
+	return NewClient(apiKey).ListRegistrations(context.Background())
+}
+
+// ExpiryDate returns the date the registration expires.
+func (r Registration) ExpiryDate() (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "2006-01-02 15:04:05"} {
+		if date, err := time.Parse(layout, r.Expires); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse expiry date %q for %s", r.Expires, r.Domain)
+}
+
+// AutoRenews reports whether the registration is set to renew automatically.
+func (r Registration) AutoRenews() bool {
+	return isTrue(r.Autorenew)
+}
+
+// AtRisk reports whether the domain could lapse: it has already expired, or it expires within window of now and won't renew automatically.
+// A registration whose expiry date can't be read is treated as at risk.
+func (r Registration) AtRisk(window time.Duration, now time.Time) bool {
+	if isTrue(r.Expired) {
+		return true
+	}
+	expires, err := r.ExpiryDate()
+	if err != nil {
+		return true
+	}
+	return !r.AutoRenews() && expires.Before(now.Add(window))
+}
+
+// AtRisk returns the registrations that could lapse within window of now.
+func (registrations Registrations) AtRisk(window time.Duration, now time.Time) []Registration {
+	var atRisk []Registration
+	for _, registration := range registrations.Data {
+		if registration.AtRisk(window, now) {
+			atRisk = append(atRisk, registration)
+		}
+	}
+	return atRisk
+}
+
+// isTrue interprets the yes/no and 0/1 flags the Dreamhost API returns as strings.
+func isTrue(flag string) bool {
+	switch strings.ToLower(strings.TrimSpace(flag)) {
+	case "1", "yes", "true", "y":
+		return true
+	}
+	return false
+}
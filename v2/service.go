@@ -0,0 +1,92 @@
+package dreamhostapi
+
+import (
+	"context"
+	"sync"
+)
+
+// DNSService is the set of DNS operations a Client provides.
+// Programs that depend on it rather than on *Client can substitute a fake in their tests, or a DryRun to see what would change.
+type DNSService interface {
+	GetDNSRecords(ctx context.Context) (DnsRecords, error)
+	AddRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error)
+	RemoveRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error)
+	UpdateDNSRecord(ctx context.Context, domain string, currentIP string, newIPAddress string, opts ...RecordOption) (CommandResult, CommandResult, error)
+}
+
+var _ DNSService = (*Client)(nil)
+var _ DNSService = (*DryRun)(nil)
+
+// A RecordedChange is an add or remove that a DryRun was asked to make.
+type RecordedChange struct {
+	Command string // "add" or "del"
+	Record  string
+	Type    string
+	Value   string
+	Comment string
+	Account string
+}
+
+// A DryRun is a DNSService that reads records from another DNSService but only records the changes it is asked to make.
+// It is safe for concurrent use.
+type DryRun struct {
+	source DNSService
+
+	mu      sync.Mutex
+	changes []RecordedChange
+}
+
+// NewDryRun returns a DryRun that lists records from source, eg a Client. If source is nil, it lists no records.
+func NewDryRun(source DNSService) *DryRun {
+	return &DryRun{source: source}
+}
+
+// GetDNSRecords returns the source's records, unaffected by any recorded changes.
+func (d *DryRun) GetDNSRecords(ctx context.Context) (DnsRecords, error) {
+	if d.source == nil {
+		return DnsRecords{Result: "success"}, nil
+	}
+	return d.source.GetDNSRecords(ctx)
+}
+
+// AddRecord records the add and returns the result the API gives for a successful one.
+func (d *DryRun) AddRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	return d.record("add", record, value, opts), nil
+}
+
+// RemoveRecord records the removal and returns the result the API gives for a successful one.
+func (d *DryRun) RemoveRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	return d.record("del", record, value, opts), nil
+}
+
+// UpdateDNSRecord records adding newIPAddress and removing currentIP, in that order.
+func (d *DryRun) UpdateDNSRecord(ctx context.Context, domain string, currentIP string, newIPAddress string, opts ...RecordOption) (CommandResult, CommandResult, error) {
+	return d.record("add", domain, newIPAddress, opts), d.record("del", domain, currentIP, opts), nil
+}
+
+// Changes returns the changes recorded so far, in the order they were asked for.
+func (d *DryRun) Changes() []RecordedChange {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]RecordedChange(nil), d.changes...)
+}
+
+// Reset forgets the recorded changes.
+func (d *DryRun) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.changes = nil
+}
+
+// record appends a change and returns the matching success result.
+func (d *DryRun) record(command string, record string, value string, opts []RecordOption) CommandResult {
+	options := newRecordOptions(opts)
+	d.mu.Lock()
+	d.changes = append(d.changes, RecordedChange{Command: command, Record: record, Type: options.recordType, Value: value, Comment: options.comment, Account: options.account})
+	d.mu.Unlock()
+	data := "record_added"
+	if command == "del" {
+		data = "record_removed"
+	}
+	return CommandResult{Result: "success", Data: data}
+}
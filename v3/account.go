@@ -0,0 +1,39 @@
+package dreamhostapi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOutsideAccountScope is returned when a command would change an account other than the one the Client is restricted to.
+var ErrOutsideAccountScope = errors.New("command is outside the client's account scope")
+
+// An AccountScopeError reports a command that names an account other than the Client's account scope; see WithAccountScope.
+type AccountScopeError struct {
+	Command string // the command that was refused, eg dns-add_record
+	Account string // the account the command named
+	Scope   string // the account the Client is restricted to
+}
+
+func (e *AccountScopeError) Error() string {
+	return fmt.Sprintf("%s: %s for account %s, but the client is restricted to account %s", ErrOutsideAccountScope, e.Command, e.Account, e.Scope)
+}
+
+func (e *AccountScopeError) Unwrap() error {
+	return ErrOutsideAccountScope
+}
+
+// checkAccount returns an AccountScopeError if the Client has an account scope and command changes the account for another account.
+func (c *Client) checkAccount(command map[string]string) error {
+	if c.accountScope == "" {
+		return nil
+	}
+	spec, ok := LookupCommand(command["cmd"])
+	if !ok || !spec.Mutating {
+		return nil
+	}
+	if account := command["account"]; account != "" && account != c.accountScope {
+		return &AccountScopeError{Command: command["cmd"], Account: account, Scope: c.accountScope}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package dreamhostapi
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// acmeChallengeLabel is the label ACME DNS-01 validation places its TXT records under.
+const acmeChallengeLabel = "_acme-challenge"
+
+// acmeOptions holds the options of StaleACMEChallenges and CleanACMEChallenges.
+type acmeOptions struct {
+	unknownAge bool
+}
+
+// An ACMEOption changes which challenge records StaleACMEChallenges and CleanACMEChallenges pick.
+type ACMEOption func(*acmeOptions)
+
+// IncludeUnknownAge also picks the _acme-challenge TXT records without CreatedKey metadata, whatever their age.
+// Those are the records left by ACME clients such as certbot, lego, and acme.sh rather than added through this package,
+// so only use it when no certificate is being issued or renewed, or a challenge in progress may be removed.
+func IncludeUnknownAge() ACMEOption {
+	return func(o *acmeOptions) {
+		o.unknownAge = true
+	}
+}
+
+// StaleACMEChallenges returns the _acme-challenge TXT records that are older than maxAge at now.
+// Dreamhost does not record when a record was created, so a record's age comes from the CreatedKey metadata in its comment,
+// which only records added through this package have. Challenge records without it, such as those left by certbot, lego, or acme.sh,
+// have no known age and are only returned with IncludeUnknownAge, since another tool may have just created them.
+func (records DnsRecords) StaleACMEChallenges(maxAge time.Duration, now time.Time, opts ...ACMEOption) DnsRecords {
+	var o acmeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	stale := DnsRecords{Result: records.Result}
+	for _, record := range records.Data {
+		if record.ZoneType != TXT {
+			continue
+		}
+		name := strings.ToLower(record.Record)
+		if name != acmeChallengeLabel && !strings.HasPrefix(name, acmeChallengeLabel+".") {
+			continue
+		}
+		created, ok := record.Created()
+		if !ok && !o.unknownAge {
+			continue
+		}
+		if ok && now.Sub(created) < maxAge {
+			continue
+		}
+		stale.Data = append(stale.Data, record)
+	}
+	return stale
+}
+
+// CleanACMEChallenges deletes the stale _acme-challenge TXT records across every zone on the account and returns them.
+// Only records whose age is known from their metadata are deleted unless IncludeUnknownAge is given; see StaleACMEChallenges.
+// With dryRun set, nothing is deleted; each record that would be removed is logged and returned instead.
+func (c *Client) CleanACMEChallenges(ctx context.Context, maxAge time.Duration, dryRun bool, opts ...ACMEOption) ([]DnsRecord, error) {
+	records, err := c.GetDNSRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stale := records.StaleACMEChallenges(maxAge, c.clock.Now(), opts...).Data
+	if dryRun {
+		for _, record := range stale {
+			c.logger.Printf("Dry run: would remove TXT record %s with value %s\n", record.Record, record.Value)
+		}
+		return stale, nil
+	}
+	return c.removeRecords(ctx, stale)
+}
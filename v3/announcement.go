@@ -0,0 +1,155 @@
+package dreamhostapi
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Subscribers holds an array of Subscriber structs returned by the Dreamhost API
+type Subscribers struct {
+	Data   []Subscriber `json:"data"`
+	Result string       `json:"result"`
+}
+
+// Subscriber is a subscriber to a Dreamhost announcement list
+type Subscriber struct {
+	Email         string // the subscriber's email address
+	Name          string // the subscriber's name, may be empty
+	SubscribeDate string `json:"subscribe_date"` // when the subscriber joined the list
+	Confirmed     string // 0 or 1 value, but comes back as a string
+}
+
+// subscriberCSVHeader is the header row written by WriteSubscribersCSV.
+var subscriberCSVHeader = []string{"email", "name", "subscribe_date", "confirmed"}
+
+// ListSubscribers returns a Subscribers struct containing everyone subscribed to the announcement list listname@domain and any errors.
+func (c *Client) ListSubscribers(ctx context.Context, listname string, domain string) (Subscribers, error) {
+	command := map[string]string{"cmd": "announcement_list-list_subscribers", "listname": listname, "domain": domain}
+	response, err := submitCommand[[]Subscriber](ctx, c, command)
+	if err != nil {
+		return Subscribers{}, err
+	}
+	return Subscribers{Data: response.Data, Result: response.Result}, nil
+}
+
+// AddSubscriber returns a CommandResult after using the Dreamhost API to add email to the announcement list listname@domain and any errors.
+func (c *Client) AddSubscriber(ctx context.Context, listname string, domain string, email string, name string) (CommandResult, error) {
+	command := map[string]string{"cmd": "announcement_list-add_subscriber", "listname": listname, "domain": domain, "email": email}
+	if name != "" {
+		command["name"] = name
+	}
+	return submitCommand[string](ctx, c, command)
+}
+
+// ExportSubscribers writes the subscribers of the announcement list listname@domain to w as CSV.
+func (c *Client) ExportSubscribers(ctx context.Context, listname string, domain string, w io.Writer) error {
+	subscribers, err := c.ListSubscribers(ctx, listname, domain)
+	if err != nil {
+		return err
+	}
+	return WriteSubscribersCSV(w, subscribers.Data)
+}
+
+// WriteSubscribersCSV writes subscribers to w as CSV with an email,name,subscribe_date,confirmed header row.
+func WriteSubscribersCSV(w io.Writer, subscribers []Subscriber) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(subscriberCSVHeader); err != nil {
+		return err
+	}
+	for _, subscriber := range subscribers {
+		if err := writer.Write([]string{subscriber.Email, subscriber.Name, subscriber.SubscribeDate, subscriber.Confirmed}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ReadSubscribersCSV reads subscribers from CSV, validating every email address and dropping duplicates.
+// If the first row is a header containing an "email" column, columns are found by name (email and name),
+// and the names are left empty if there is no "name" column.
+// Otherwise the first column is the email address and the optional second column is the name.
+func ReadSubscribersCSV(r io.Reader) ([]Subscriber, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	emailColumn, nameColumn, firstRow := 0, 1, 0
+	if len(rows) > 0 {
+		header := make(map[string]int)
+		for i, column := range rows[0] {
+			header[strings.ToLower(strings.TrimSpace(column))] = i
+		}
+		if i, ok := header["email"]; ok {
+			emailColumn, nameColumn, firstRow = i, -1, 1
+			if i, ok := header["name"]; ok {
+				nameColumn = i
+			}
+		}
+	}
+	var subscribers []Subscriber
+	seen := make(map[string]bool)
+	for i := firstRow; i < len(rows); i++ {
+		row := rows[i]
+		if emailColumn >= len(row) || strings.TrimSpace(row[emailColumn]) == "" {
+			continue
+		}
+		address, err := mail.ParseAddress(strings.TrimSpace(row[emailColumn]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid email address %q: %w", i+1, row[emailColumn], err)
+		}
+		key := strings.ToLower(address.Address)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		subscriber := Subscriber{Email: address.Address}
+		if nameColumn >= 0 && nameColumn < len(row) && nameColumn != emailColumn {
+			subscriber.Name = strings.TrimSpace(row[nameColumn])
+		}
+		subscribers = append(subscribers, subscriber)
+	}
+	return subscribers, nil
+}
+
+// ImportSubscribers adds subscribers to the announcement list listname@domain and returns the ones that were added.
+// Anyone already subscribed is skipped. The adds are applied one at a time with delay between them to stay under the API rate limit.
+// It keeps going when an add fails and returns all of the failures joined together.
+func (c *Client) ImportSubscribers(ctx context.Context, listname string, domain string, subscribers []Subscriber, delay time.Duration) ([]Subscriber, error) {
+	current, err := c.ListSubscribers(ctx, listname, domain)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for _, subscriber := range current.Data {
+		existing[strings.ToLower(subscriber.Email)] = true
+	}
+	var added []Subscriber
+	var errs []error
+	for _, subscriber := range subscribers {
+		key := strings.ToLower(subscriber.Email)
+		if existing[key] {
+			continue
+		}
+		if len(added) > 0 || len(errs) > 0 {
+			if err := c.clock.Sleep(ctx, delay); err != nil {
+				return added, errors.Join(append(errs, err)...)
+			}
+		}
+		if _, err := c.AddSubscriber(ctx, listname, domain, subscriber.Email, subscriber.Name); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", subscriber.Email, err))
+			continue
+		}
+		existing[key] = true
+		added = append(added, subscriber)
+	}
+	return added, errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+package dreamhostapi
+
+import "context"
+
+// An AsyncResult is the outcome of a record change submitted with AddRecordAsync or RemoveRecordAsync.
+type AsyncResult struct {
+	Result CommandResult
+	Err    error
+}
+
+// asyncJob is a record change waiting in the Client's queue.
+type asyncJob struct {
+	ctx     context.Context
+	command string // "add" or "del"
+	record  string
+	value   string
+	opts    []RecordOption
+	done    chan AsyncResult
+}
+
+// AddRecordAsync queues adding value to record and returns at once with a channel that receives the outcome.
+// Queued changes run one at a time in the order they were submitted, waiting out rate limits as the Client's BackoffStrategy decides,
+// so a program can pipeline many changes without managing goroutines itself. Cancelling ctx abandons the change if it hasn't run yet.
+// The channel receives exactly one AsyncResult and is then closed.
+func (c *Client) AddRecordAsync(ctx context.Context, record string, value string, opts ...RecordOption) <-chan AsyncResult {
+	return c.enqueue(asyncJob{ctx: ctx, command: "add", record: record, value: value, opts: opts})
+}
+
+// RemoveRecordAsync is like AddRecordAsync, but queues removing value from record.
+func (c *Client) RemoveRecordAsync(ctx context.Context, record string, value string, opts ...RecordOption) <-chan AsyncResult {
+	return c.enqueue(asyncJob{ctx: ctx, command: "del", record: record, value: value, opts: opts})
+}
+
+// enqueue adds job to the Client's queue, starting a worker if none is running.
+func (c *Client) enqueue(job asyncJob) <-chan AsyncResult {
+	job.done = make(chan AsyncResult, 1)
+	c.asyncMu.Lock()
+	c.asyncQueue = append(c.asyncQueue, job)
+	if !c.asyncRunning {
+		c.asyncRunning = true
+		go c.runQueue()
+	}
+	c.asyncMu.Unlock()
+	return job.done
+}
+
+// runQueue runs queued jobs in order until the queue is empty, then exits.
+func (c *Client) runQueue() {
+	for {
+		c.asyncMu.Lock()
+		if len(c.asyncQueue) == 0 {
+			c.asyncRunning = false
+			c.asyncMu.Unlock()
+			return
+		}
+		job := c.asyncQueue[0]
+		c.asyncQueue = c.asyncQueue[1:]
+		c.asyncMu.Unlock()
+		var result AsyncResult
+		if err := job.ctx.Err(); err != nil {
+			result.Err = err
+		} else {
+			result.Result, result.Err = c.changeRecord(job.ctx, job.command, job.record, job.value, job.opts...)
+		}
+		job.done <- result
+		close(job.done)
+	}
+}
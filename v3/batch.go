@@ -0,0 +1,68 @@
+package dreamhostapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// A Command is one Dreamhost API command for Batch.
+type Command struct {
+	Cmd    string            // the command name, eg dns-add_record
+	Params map[string]string // the command's parameters, without key, cmd, or format
+}
+
+// A BatchResult is the outcome of one Command in a Batch.
+type BatchResult struct {
+	Command  Command
+	Envelope Envelope // the decoded response, empty if the command was never answered
+	Err      error    // nil if the command succeeded
+}
+
+// AddRecordCommand returns the Command that adds value to record, as Client.AddRecord would send it, and any error in the value.
+func AddRecordCommand(record string, value string, opts ...RecordOption) (Command, error) {
+	return newRecordCommand("add", record, value, opts)
+}
+
+// RemoveRecordCommand returns the Command that removes value from record, as Client.RemoveRecord would send it, and any error in the value.
+func RemoveRecordCommand(record string, value string, opts ...RecordOption) (Command, error) {
+	return newRecordCommand("del", record, value, opts)
+}
+
+// newRecordCommand builds the Command for AddRecordCommand and RemoveRecordCommand.
+func newRecordCommand(command string, record string, value string, opts []RecordOption) (Command, error) {
+	params, err := recordCommand(command, record, value, newRecordOptions(opts))
+	if err != nil {
+		return Command{}, err
+	}
+	cmd := params["cmd"]
+	delete(params, "cmd")
+	return Command{Cmd: cmd, Params: params}, nil
+}
+
+// Batch runs commands one after another, in order, and returns a BatchResult for each, so a command may depend on the ones before it.
+// With WithBatchConcurrency it runs that many at once instead, and the commands must then be independent of each other.
+// A failing command doesn't stop the rest; the returned error joins every failure, each labelled with its position and command name,
+// and is nil only if all of them succeeded. If ctx is done, the commands not yet run fail with ctx.Err().
+// The hosted-zone and CNAME pre-flights apply to dns-add_record commands as they do to AddRecord.
+func (c *Client) Batch(ctx context.Context, commands []Command) ([]BatchResult, error) {
+	results := make([]BatchResult, len(commands))
+	tasks := make([]Task, len(commands))
+	for i, command := range commands {
+		results[i].Command = command
+		tasks[i] = Task{Name: fmt.Sprintf("command %d (%s)", i, command.Cmd), Run: func(ctx context.Context) error {
+			if command.Cmd == "dns-add_record" {
+				if err := c.checkAdd(ctx, command.Params["record"], RecordType(command.Params["type"])); err != nil {
+					return err
+				}
+			}
+			var err error
+			results[i].Envelope, err = c.SubmitCommand(ctx, command.Cmd, command.Params)
+			return err
+		}}
+	}
+	report := NewPool(c.batchWorkers).Run(ctx, tasks)
+	for i, result := range report.Results {
+		results[i].Err = result.Err
+	}
+	return results, report.Err()
+}
@@ -0,0 +1,253 @@
+package dreamhostapi
+
+import (
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// A Client talks to the Dreamhost API with one API key and one set of configuration.
+// Create it with NewClient; the zero value is not usable.
+//
+// A Client is safe for concurrent use by multiple goroutines, and is meant to be shared:
+// its goroutines share one HTTP client, one set of caches, and one rate limiter, so a rate-limit pause holds them all back.
+type Client struct {
+	apiKey        string
+	httpClient    *http.Client
+	baseURL       string
+	logger        *log.Logger
+	backoff       BackoffStrategy
+	decoder       Decoder
+	checkZones    bool
+	usePOST       bool
+	keyProvider   KeyProvider
+	checkScopes   bool
+	cnamePolicy   CNAMEPolicy
+	userAgent     string
+	concurrency   int
+	batchWorkers  int
+	clock         Clock
+	middleware    []Middleware
+	responseHooks []ResponseHook
+	zoneCacheTTL  time.Duration
+	accountScope  string
+	format        Format
+	recordTTL     time.Duration
+
+	limiter limiter
+
+	hooksMu sync.RWMutex // guards middleware and responseHooks
+
+	zoneCacheMu sync.Mutex
+	zoneCache   hostedZones
+
+	scopeCacheMu sync.Mutex
+	scopeCache   keyScope
+
+	recordCacheMu sync.Mutex
+	recordCache   cachedRecords
+
+	asyncMu      sync.Mutex
+	asyncQueue   []asyncJob
+	asyncRunning bool
+}
+
+// DefaultHTTPClient is the HTTP client new Clients start with.
+// Unlike http.DefaultClient it has a timeout, so a hung connection can't block forever.
+// Replace it to change the HTTP client the package-level functions use.
+var DefaultHTTPClient = &http.Client{Timeout: 60 * time.Second}
+
+// DefaultBaseURL is the public Dreamhost API endpoint.
+const DefaultBaseURL = "https://api.dreamhost.com/"
+
+// BaseURL is the API endpoint new Clients start with.
+// Replace it to point the package-level functions at a test server, proxy, or staging endpoint.
+var BaseURL = DefaultBaseURL
+
+// Version is the version of this package, sent in the default User-Agent header.
+const Version = "3.0.0"
+
+// DefaultUserAgent is the User-Agent header new Clients send.
+const DefaultUserAgent = "dreamhostapi-go/" + Version
+
+// An Option configures a Client.
+type Option func(*Client)
+
+// NewClient returns a Client that uses apiKey for every command.
+// Without options it behaves like the package-level functions: it uses DefaultHTTPClient, BaseURL,
+// the standard logger, RateLimitBackoff, JSONDecoder, FormatJSON, CheckZoneBeforeAdd, HostedZoneCacheTTL, DefaultUserAgent, DefaultConcurrency, and SystemClock.
+func NewClient(apiKey string, options ...Option) *Client {
+	c := &Client{
+		apiKey:       apiKey,
+		httpClient:   DefaultHTTPClient,
+		baseURL:      BaseURL,
+		logger:       log.Default(),
+		backoff:      RateLimitBackoff,
+		decoder:      JSONDecoder,
+		format:       FormatJSON,
+		checkZones:   CheckZoneBeforeAdd,
+		zoneCacheTTL: HostedZoneCacheTTL,
+		userAgent:    DefaultUserAgent,
+		concurrency:  DefaultConcurrency,
+		clock:        SystemClock,
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// WithHTTPClient makes the Client send its requests with httpClient.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTransport makes the Client send its requests through transport, eg to add a proxy or instrumentation.
+// The rest of the HTTP client's settings, such as its timeout, are kept.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		httpClient := *c.httpClient
+		httpClient.Transport = transport
+		c.httpClient = &httpClient
+	}
+}
+
+// WithBaseURL makes the Client send its requests to baseURL instead of BaseURL,
+// eg a local test server, a corporate proxy, or a staging endpoint.
+// Any query parameters already in baseURL are kept alongside the command's.
+// An unparsable baseURL is reported by the first call that uses it.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithLogger makes the Client write failed responses and rate-limit pauses to logger.
+func WithLogger(logger *log.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithBackoff sets the retry policy the Client follows when the API rate limits a request.
+func WithBackoff(backoff BackoffStrategy) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+// WithDecoder sets the Decoder the Client unmarshals API responses with.
+func WithDecoder(decoder Decoder) Option {
+	return func(c *Client) {
+		c.decoder = decoder
+	}
+}
+
+// WithZoneCheck turns the hosted-zone pre-flight on adds on or off. See Client.CheckZoneHosted.
+func WithZoneCheck(enabled bool) Option {
+	return func(c *Client) {
+		c.checkZones = enabled
+	}
+}
+
+// WithScopeCheck turns the scope pre-flight on or off.
+// When it is on, a command that changes the account, such as dns-add_record, is only sent if api-list_accessible_cmds lists it for the API key;
+// otherwise an InsufficientScopeError is returned.
+func WithScopeCheck(enabled bool) Option {
+	return func(c *Client) {
+		c.checkScopes = enabled
+	}
+}
+
+// WithZoneCacheTTL sets how long the Client reuses the list of hosted domains for the hosted-zone pre-flight,
+// and the API key's scope for the scope pre-flight.
+func WithZoneCacheTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.zoneCacheTTL = ttl
+	}
+}
+
+// WithRecordCache makes GetDNSRecordsByZone reuse the account's record listing for ttl rather than fetching it for every call.
+// The cache is dropped whenever the Client sends a command that changes the account. It is off by default.
+func WithRecordCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.recordTTL = ttl
+	}
+}
+
+// WithPOST makes the Client send commands, including the API key, in a POST form body instead of the URL's query string,
+// keeping the key out of proxy and server access logs.
+// If the endpoint answers a POST with 405 Method Not Allowed, the command is sent again with GET.
+func WithPOST(enabled bool) Option {
+	return func(c *Client) {
+		c.usePOST = enabled
+	}
+}
+
+// WithKeyProvider makes the Client ask provider for the API key before every command instead of using the key it was created with.
+func WithKeyProvider(provider KeyProvider) Option {
+	return func(c *Client) {
+		c.keyProvider = provider
+	}
+}
+
+// WithUserAgent adds product, eg "my-ddns/1.2", to the User-Agent header after DefaultUserAgent,
+// so the requests can be told apart in Dreamhost's and proxies' logs.
+func WithUserAgent(product string) Option {
+	return func(c *Client) {
+		c.userAgent = DefaultUserAgent + " " + product
+	}
+}
+
+// WithConcurrency sets how many commands the Client runs at once in bulk operations, such as EnsureOnly, Expire, and CleanACMEChallenges.
+func WithConcurrency(concurrency int) Option {
+	return func(c *Client) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithBatchConcurrency makes Batch run up to concurrency commands at once rather than one after another.
+// The commands in a parallel batch may run in any order, so they must be independent of each other,
+// eg not an add followed by the removal of the value it replaces.
+func WithBatchConcurrency(concurrency int) Option {
+	return func(c *Client) {
+		c.batchWorkers = concurrency
+	}
+}
+
+// WithClock sets the Clock the Client reads the time from and waits with, eg to skip rate-limit pauses in tests.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.clock = clock
+	}
+}
+
+// WithRateLimit spaces the Client's requests at least interval apart, across all the goroutines using it,
+// to stay under the API's rate limit instead of waiting it out.
+func WithRateLimit(interval time.Duration) Option {
+	return func(c *Client) {
+		c.limiter.interval = interval
+	}
+}
+
+// WithCNAMECheck sets what the Client does when an add would put a CNAME record next to other records at the same name,
+// or another record next to a CNAME, which DNS doesn't allow. See CNAMEPolicy.
+// The check reads the record listing, using the one cached by WithRecordCache while it is fresh.
+func WithCNAMECheck(policy CNAMEPolicy) Option {
+	return func(c *Client) {
+		c.cnamePolicy = policy
+	}
+}
+
+// WithAccountScope restricts the Client to the account with the ID account, for keys that can reach more than one.
+// GetDNSRecords and ListDomains only return the records and domains of that account,
+// commands that change the account are sent for it, and one sent for another account with WithAccount
+// or an account parameter fails with an AccountScopeError without calling the API.
+func WithAccountScope(account string) Option {
+	return func(c *Client) {
+		c.accountScope = account
+	}
+}
@@ -0,0 +1,136 @@
+package dreamhostapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeAPI is an httptest server that keeps a set of records for dns-list_records, dns-add_record, and dns-remove_record,
+// and answers every rateLimitEvery-th request with 429 Too Many Requests.
+type fakeAPI struct {
+	*httptest.Server
+	rateLimitEvery int64
+
+	requests atomic.Int64
+	mu       sync.Mutex
+	records  []DnsRecord
+}
+
+func newFakeAPI(t *testing.T, rateLimitEvery int64) *fakeAPI {
+	api := &fakeAPI{rateLimitEvery: rateLimitEvery}
+	api.Server = httptest.NewServer(http.HandlerFunc(api.serve))
+	t.Cleanup(api.Close)
+	return api
+}
+
+func (api *fakeAPI) serve(w http.ResponseWriter, r *http.Request) {
+	if n := api.requests.Add(1); api.rateLimitEvery > 0 && n%api.rateLimitEvery == 0 {
+		w.WriteHeader(http.StatusTooManyRequests)
+		return
+	}
+	query := r.URL.Query()
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	switch query.Get("cmd") {
+	case "dns-list_records":
+		json.NewEncoder(w).Encode(map[string]any{"result": "success", "data": api.records})
+	case "dns-add_record":
+		api.records = append(api.records, DnsRecord{Record: query.Get("record"), Zone: "example.com", Value: query.Get("value"), ZoneType: RecordType(query.Get("type")), Editable: true})
+		fmt.Fprint(w, `{"result":"success","data":"record_added"}`)
+	case "dns-remove_record":
+		for i, record := range api.records {
+			if record.Record == query.Get("record") && record.Value == query.Get("value") {
+				api.records = append(api.records[:i], api.records[i+1:]...)
+				break
+			}
+		}
+		fmt.Fprint(w, `{"result":"success","data":"record_removed"}`)
+	default:
+		fmt.Fprint(w, `{"result":"error","data":"no_such_cmd"}`)
+	}
+}
+
+// TestClientConcurrentUse shares one Client between many goroutines that list, look up, add, and remove records
+// while hooks are added, the rate limiter paces them, and the API rate limits some requests.
+// Run it with go test -race.
+func TestClientConcurrentUse(t *testing.T) {
+	api := newFakeAPI(t, 7)
+	client := NewClient("key",
+		WithBaseURL(api.URL),
+		WithLogger(log.New(io.Discard, "", 0)),
+		WithBackoff(ConstantBackoff{Delay: time.Millisecond}),
+		WithRateLimit(10*time.Microsecond),
+		WithRecordCache(time.Minute),
+	)
+	var responses atomic.Int64
+	client.OnResponse(func(string, int, []byte, error) {
+		responses.Add(1)
+	})
+
+	ctx := context.Background()
+	const workers, iterations = 16, 20
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*iterations)
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range iterations {
+				name := fmt.Sprintf("host%d.example.com", worker)
+				value := fmt.Sprintf("192.0.2.%d", i)
+				var err error
+				switch i % 4 {
+				case 0:
+					_, err = client.AddRecord(ctx, name, value)
+				case 1:
+					_, err = client.GetDNSRecordsByZone(ctx, "example.com")
+				case 2:
+					_, _, err = client.FindRecord(ctx, name, A)
+				case 3:
+					_, err = client.RemoveRecord(ctx, name, fmt.Sprintf("192.0.2.%d", i-3))
+				}
+				if err != nil {
+					errs <- fmt.Errorf("worker %d, iteration %d: %w", worker, i, err)
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range iterations {
+			client.OnResponse(func(string, int, []byte, error) {})
+		}
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if got, want := responses.Load(), api.requests.Load(); got != want {
+		t.Errorf("response hook saw %d responses, the server answered %d requests", got, want)
+	}
+	if _, err := client.AddRecord(ctx, "last.example.com", "192.0.2.200"); err != nil {
+		t.Fatal(err)
+	}
+	records, err := client.GetDNSRecordsByZone(ctx, "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := records.Find("last.example.com", A); !ok {
+		t.Error("record cache still served the listing from before the last add")
+	}
+	if got := len(records.Data); got != 1 {
+		t.Errorf("got %d records after every worker removed the records it added, want only the last one", got)
+	}
+}
@@ -0,0 +1,27 @@
+package dreamhostapi
+
+import (
+	"context"
+	"time"
+)
+
+// A Clock tells the Client the time and makes it wait, eg between rate-limited retries.
+// Replace it with WithClock to make waits instant in tests.
+type Clock interface {
+	Now() time.Time
+	// Sleep waits for d, returning early with ctx.Err() if ctx is done first.
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// SystemClock is the Clock new Clients start with: the real time, and waits that end early when the context is cancelled.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error {
+	return sleep(ctx, d)
+}
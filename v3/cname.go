@@ -0,0 +1,71 @@
+package dreamhostapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrCNAMEConflict is returned when an add would break the rule that a CNAME record can't share its name with any other record.
+var ErrCNAMEConflict = errors.New("a CNAME record can't share its name with other records")
+
+// A CNAMEConflictError reports an add refused by the CNAME pre-flight.
+type CNAMEConflictError struct {
+	Record   string      // the name being added to
+	Type     RecordType  // the type being added
+	Existing []DnsRecord // the records it would clash with
+}
+
+func (e *CNAMEConflictError) Error() string {
+	return fmt.Sprintf("%s: adding %s record to %s clashes with %d existing record(s)", ErrCNAMEConflict, e.Type, e.Record, len(e.Existing))
+}
+
+func (e *CNAMEConflictError) Unwrap() error {
+	return ErrCNAMEConflict
+}
+
+// A CNAMEPolicy says what the CNAME pre-flight does about an add that breaks the CNAME coexistence rule.
+type CNAMEPolicy int
+
+const (
+	CNAMEAllow  CNAMEPolicy = iota // don't check; the default
+	CNAMEWarn                      // log the conflict and add the record anyway
+	CNAMERefuse                    // return a CNAMEConflictError without adding the record
+)
+
+// CNAMEConflicts returns the records that a new record named name of type recordType would clash with:
+// every other record at the name if recordType is CNAME, otherwise any CNAME record at the name.
+func (records DnsRecords) CNAMEConflicts(name string, recordType RecordType) []DnsRecord {
+	var conflicts []DnsRecord
+	for _, record := range records.Data {
+		if !record.Matches(name, "") {
+			continue
+		}
+		if recordType == CNAME || record.ZoneType == CNAME {
+			conflicts = append(conflicts, record)
+		}
+	}
+	return conflicts
+}
+
+// checkCNAME applies the Client's CNAMEPolicy to adding a record named name of type recordType.
+// It checks against the record listing from listRecords, so a listing cached by WithRecordCache is used rather than fetched again.
+func (c *Client) checkCNAME(ctx context.Context, name string, recordType RecordType) error {
+	if c.cnamePolicy == CNAMEAllow {
+		return nil
+	}
+	records, err := c.listRecords(ctx)
+	if err != nil {
+		return err
+	}
+	conflicts := records.CNAMEConflicts(name, recordType)
+	if len(conflicts) == 0 {
+		return nil
+	}
+	conflictErr := &CNAMEConflictError{Record: name, Type: recordType, Existing: conflicts}
+	if c.cnamePolicy == CNAMEWarn {
+		c.logger.Println(conflictErr)
+		return nil
+	}
+	return conflictErr
+}
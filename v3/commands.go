@@ -0,0 +1,165 @@
+package dreamhostapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// A CommandSpec describes a Dreamhost API command and the parameters it takes, so malformed commands can be rejected before they are sent.
+type CommandSpec struct {
+	Name     string
+	Required []string                             // parameters that must be present and non-empty
+	Optional []string                             // parameters that may be present
+	Mutating bool                                 // whether the command changes anything on the account
+	Validate func(params map[string]string) error // further checks on the parameter values, may be nil
+}
+
+// A ValidationError reports a command or value that was rejected locally, before anything was sent to the API.
+type ValidationError struct {
+	Command string // the command being checked, empty for checks not tied to one command
+	Field   string // the parameter at fault
+	Value   string // the offending value, if any
+	Reason  string // what is wrong with it
+}
+
+func (e *ValidationError) Error() string {
+	message := fmt.Sprintf("%s %q %s", e.Field, e.Value, e.Reason)
+	if e.Value == "" {
+		message = fmt.Sprintf("%s %s", e.Field, e.Reason)
+	}
+	if e.Command == "" {
+		return message
+	}
+	return e.Command + ": " + message
+}
+
+// globalParameters are accepted by every command.
+var globalParameters = map[string]bool{"key": true, "cmd": true, "format": true, "unique_id": true, "account": true}
+
+var commandRegistry = struct {
+	sync.RWMutex
+	specs map[string]CommandSpec
+}{specs: make(map[string]CommandSpec)}
+
+func init() {
+	for _, spec := range []CommandSpec{
+		{Name: "api-list_accessible_cmds"},
+		{Name: "account-domain_usage"},
+		{Name: "account-list_keys"},
+		{Name: "account-status"},
+		{Name: "account-user_usage"},
+		{Name: "announcement_list-list_lists"},
+		{Name: "announcement_list-list_subscribers", Required: []string{"listname", "domain"}},
+		{Name: "announcement_list-add_subscriber", Required: []string{"listname", "domain", "email"}, Optional: []string{"name"}, Mutating: true},
+		{Name: "announcement_list-remove_subscriber", Required: []string{"listname", "domain", "email"}, Mutating: true},
+		{Name: "announcement_list-post_announcement", Required: []string{"listname", "domain", "subject", "message", "name"}, Optional: []string{"stamp", "charset", "type", "duplicate_ok"}, Mutating: true},
+		{Name: "dns-list_records"},
+		{Name: "dns-add_record", Required: []string{"record", "type", "value"}, Optional: []string{"comment"}, Mutating: true, Validate: validateNewRecord},
+		{Name: "dns-remove_record", Required: []string{"record", "type", "value"}, Optional: []string{"comment"}, Mutating: true, Validate: validateRecordType},
+		{Name: "domain-list_domains"},
+		{Name: "domain-list_registrations"},
+		{Name: "domain-registration-availability", Required: []string{"domain"}},
+		{Name: "dreamhost_ps-list_ps"},
+		{Name: "dreamhost_ps-list_pending_ps"},
+		{Name: "dreamhost_ps-list_settings", Required: []string{"ps"}},
+		{Name: "dreamhost_ps-list_size_history", Required: []string{"ps"}},
+		{Name: "dreamhost_ps-set_size", Required: []string{"ps", "size"}, Mutating: true},
+		{Name: "dreamhost_ps-list_reboot_history", Required: []string{"ps"}},
+		{Name: "dreamhost_ps-reboot", Required: []string{"ps"}, Mutating: true},
+		{Name: "dreamhost_ps-list_usage", Required: []string{"ps"}},
+		{Name: "mail-list_filters"},
+		{Name: "mail-add_filter", Required: []string{"address", "filter_on", "filter", "action"}, Optional: []string{"action_value", "contains", "stop", "rank"}, Mutating: true},
+		{Name: "mail-remove_filter", Required: []string{"address", "filter_on", "filter", "action"}, Optional: []string{"action_value", "contains", "stop", "rank"}, Mutating: true},
+		{Name: "mysql-list_dbs"},
+		{Name: "mysql-list_hostnames"},
+		{Name: "mysql-list_users"},
+		{Name: "mysql-add_hostname", Required: []string{"hostname"}, Mutating: true},
+		{Name: "mysql-remove_hostname", Required: []string{"hostname"}, Mutating: true},
+		{Name: "user-list_users"},
+		{Name: "user-list_users_no_pw"},
+	} {
+		RegisterCommand(spec)
+	}
+}
+
+// RegisterCommand adds spec to the registry, replacing any existing spec with the same name.
+// Use it to describe commands the package doesn't know about, so SubmitCommand can check them too.
+func RegisterCommand(spec CommandSpec) {
+	commandRegistry.Lock()
+	defer commandRegistry.Unlock()
+	commandRegistry.specs[spec.Name] = spec
+}
+
+// LookupCommand returns the registered spec for the named command and whether there was one.
+func LookupCommand(name string) (CommandSpec, bool) {
+	commandRegistry.RLock()
+	defer commandRegistry.RUnlock()
+	spec, ok := commandRegistry.specs[name]
+	return spec, ok
+}
+
+// Commands returns the names of all registered commands, sorted.
+func Commands() []string {
+	commandRegistry.RLock()
+	defer commandRegistry.RUnlock()
+	names := make([]string, 0, len(commandRegistry.specs))
+	for name := range commandRegistry.specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check returns a ValidationError if params is missing a required parameter, has one the command doesn't take, or fails the spec's Validate function.
+func (spec CommandSpec) Check(params map[string]string) error {
+	for _, name := range spec.Required {
+		if strings.TrimSpace(params[name]) == "" {
+			return &ValidationError{Command: spec.Name, Field: name, Reason: "is required"}
+		}
+	}
+	allowed := make(map[string]bool, len(spec.Required)+len(spec.Optional))
+	for _, name := range spec.Required {
+		allowed[name] = true
+	}
+	for _, name := range spec.Optional {
+		allowed[name] = true
+	}
+	for name := range params {
+		if !allowed[name] && !globalParameters[name] {
+			return &ValidationError{Command: spec.Name, Field: name, Reason: "is not a parameter of this command"}
+		}
+	}
+	if spec.Validate != nil {
+		return spec.Validate(params)
+	}
+	return nil
+}
+
+// checkCommand validates command against the registry. Commands that aren't registered are passed through unchecked.
+func checkCommand(command map[string]string) error {
+	spec, ok := LookupCommand(command["cmd"])
+	if !ok {
+		return nil
+	}
+	return spec.Check(command)
+}
+
+// validateNewRecord checks the parameters of the dns-add_record command with ValidateRecord.
+func validateNewRecord(params map[string]string) error {
+	err := ValidateRecord(params["record"], RecordType(params["type"]), params["value"])
+	if validationErr, ok := err.(*ValidationError); ok {
+		validationErr.Command = params["cmd"]
+	}
+	return err
+}
+
+// validateRecordType checks the type parameter of the dns-remove_record command.
+// Existing records are not otherwise checked, so malformed ones can still be removed.
+func validateRecordType(params map[string]string) error {
+	if RecordType(params["type"]).Valid() {
+		return nil
+	}
+	return &ValidationError{Command: params["cmd"], Field: "type", Value: params["type"], Reason: "is not one of " + recordTypeList()}
+}
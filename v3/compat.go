@@ -0,0 +1,164 @@
+package dreamhostapi
+
+// This file holds the package-level functions of v2, which take an API key instead of being methods on a Client.
+// Each makes a new Client with default options and calls the matching method with context.Background(),
+// so v2 programs can move to v3 one call at a time.
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// CleanACMEChallenges is a shortcut for NewClient(apiKey).CleanACMEChallenges(context.Background(), maxAge, dryRun).
+//
+// Deprecated: Use Client.CleanACMEChallenges.
+func CleanACMEChallenges(apiKey string, maxAge time.Duration, dryRun bool) ([]DnsRecord, error) {
+	return NewClient(apiKey).CleanACMEChallenges(context.Background(), maxAge, dryRun)
+}
+
+// ListSubscribers is a shortcut for NewClient(apiKey).ListSubscribers(context.Background(), listname, domain).
+//
+// Deprecated: Use Client.ListSubscribers.
+func ListSubscribers(listname string, domain string, apiKey string) (Subscribers, error) {
+	return NewClient(apiKey).ListSubscribers(context.Background(), listname, domain)
+}
+
+// AddSubscriber is a shortcut for NewClient(apiKey).AddSubscriber(context.Background(), listname, domain, email, name).
+//
+// Deprecated: Use Client.AddSubscriber.
+func AddSubscriber(listname string, domain string, email string, name string, apiKey string) (CommandResult, error) {
+	return NewClient(apiKey).AddSubscriber(context.Background(), listname, domain, email, name)
+}
+
+// ExportSubscribers is a shortcut for NewClient(apiKey).ExportSubscribers(context.Background(), listname, domain, w).
+//
+// Deprecated: Use Client.ExportSubscribers.
+func ExportSubscribers(listname string, domain string, apiKey string, w io.Writer) error {
+	return NewClient(apiKey).ExportSubscribers(context.Background(), listname, domain, w)
+}
+
+// ImportSubscribers is a shortcut for NewClient(apiKey).ImportSubscribers(context.Background(), listname, domain, subscribers, delay).
+//
+// Deprecated: Use Client.ImportSubscribers.
+func ImportSubscribers(listname string, domain string, subscribers []Subscriber, apiKey string, delay time.Duration) ([]Subscriber, error) {
+	return NewClient(apiKey).ImportSubscribers(context.Background(), listname, domain, subscribers, delay)
+}
+
+// DelegateSubdomain is a shortcut for NewClient(apiKey).DelegateSubdomain(context.Background(), name, nameservers).
+//
+// Deprecated: Use Client.DelegateSubdomain.
+func DelegateSubdomain(name string, nameservers []string, apiKey string) error {
+	return NewClient(apiKey).DelegateSubdomain(context.Background(), name, nameservers)
+}
+
+// RemoveDelegation is a shortcut for NewClient(apiKey).RemoveDelegation(context.Background(), name).
+//
+// Deprecated: Use Client.RemoveDelegation.
+func RemoveDelegation(name string, apiKey string) error {
+	return NewClient(apiKey).RemoveDelegation(context.Background(), name)
+}
+
+// ListDomains is a shortcut for NewClient(apiKey).ListDomains(context.Background()).
+//
+// Deprecated: Use Client.ListDomains.
+func ListDomains(apiKey string) (Domains, error) {
+	return NewClient(apiKey).ListDomains(context.Background())
+}
+
+// CheckZoneHosted is a shortcut for NewClient(apiKey).CheckZoneHosted(context.Background(), record).
+// Since it makes a new Client every time, the domain list is fetched on every call.
+//
+// Deprecated: Use Client.CheckZoneHosted.
+func CheckZoneHosted(record string, apiKey string) (string, error) {
+	return NewClient(apiKey).CheckZoneHosted(context.Background(), record)
+}
+
+// SubmitCommand is a shortcut for NewClient(apiKey).SubmitCommand(context.Background(), cmd, params).
+//
+// Deprecated: Use Client.SubmitCommand.
+func SubmitCommand(cmd string, params map[string]string, apiKey string) (Envelope, error) {
+	return NewClient(apiKey).SubmitCommand(context.Background(), cmd, params)
+}
+
+// GetDNSRecords is a shortcut for NewClient(apiKey).GetDNSRecords(context.Background()).
+//
+// Deprecated: Use Client.GetDNSRecords.
+func GetDNSRecords(apiKey string) (DnsRecords, error) {
+	return NewClient(apiKey).GetDNSRecords(context.Background())
+}
+
+// UpdateZoneFile adds IPAddress to domain as an A record when command is "add", and removes it when command is "del".
+//
+// Deprecated: Use Client.AddRecord or Client.RemoveRecord.
+func UpdateZoneFile(command string, domain string, IPAddress string, apiKey string, comment string) (CommandResult, error) {
+	return NewClient(apiKey).changeRecord(context.Background(), command, domain, IPAddress, WithComment(comment))
+}
+
+// UpdateDNSRecord is a shortcut for NewClient(apiKey).UpdateDNSRecord(context.Background(), domain, currentIP, newIPAddress, WithComment(comment)).
+//
+// Deprecated: Use Client.UpdateDNSRecord.
+func UpdateDNSRecord(domain string, currentIP string, newIPAddress string, apiKey string, comment string) (CommandResult, CommandResult, error) {
+	return NewClient(apiKey).UpdateDNSRecord(context.Background(), domain, currentIP, newIPAddress, WithComment(comment))
+}
+
+// EnsureRecord is a shortcut for NewClient(apiKey).EnsureRecord(context.Background(), record, recordType, value, comment).
+//
+// Deprecated: Use Client.EnsureRecord.
+func EnsureRecord(record string, recordType string, value string, apiKey string, comment string) (bool, error) {
+	return NewClient(apiKey).EnsureRecord(context.Background(), record, RecordType(recordType), value, comment)
+}
+
+// EnsureOnly is a shortcut for NewClient(apiKey).EnsureOnly(context.Background(), name, recordType, values, comment).
+//
+// Deprecated: Use Client.EnsureOnly.
+func EnsureOnly(name string, recordType string, values []string, apiKey string, comment string) ([]string, []string, error) {
+	return NewClient(apiKey).EnsureOnly(context.Background(), name, RecordType(recordType), values, comment)
+}
+
+// Expire is a shortcut for NewClient(apiKey).Expire(context.Background()).
+//
+// Deprecated: Use Client.Expire.
+func Expire(apiKey string) ([]DnsRecord, error) {
+	return NewClient(apiKey).Expire(context.Background())
+}
+
+// ListMX is a shortcut for NewClient(apiKey).ListMX(context.Background(), domain).
+//
+// Deprecated: Use Client.ListMX.
+func ListMX(domain string, apiKey string) ([]MXRecord, error) {
+	return NewClient(apiKey).ListMX(context.Background(), domain)
+}
+
+// ListRegistrations is a shortcut for NewClient(apiKey).ListRegistrations(context.Background()).
+//
+// Deprecated: Use Client.ListRegistrations.
+func ListRegistrations(apiKey string) (Registrations, error) {
+	return NewClient(apiKey).ListRegistrations(context.Background())
+}
+
+// ValidateAPIKey is a shortcut for NewClient(apiKey).ValidateAPIKey(context.Background()).
+//
+// Deprecated: Use Client.ValidateAPIKey.
+func ValidateAPIKey(apiKey string) (KeyScope, error) {
+	return NewClient(apiKey).ValidateAPIKey(context.Background())
+}
+
+// ApplyVerification is a shortcut for NewClient(apiKey).ApplyVerification(context.Background(), provider, domain, token).
+//
+// Deprecated: Use Client.ApplyVerification.
+func ApplyVerification(provider string, domain string, token string, apiKey string) error {
+	return NewClient(apiKey).ApplyVerification(context.Background(), provider, domain, token)
+}
+
+// WebGet returns the body as a string, an int representing the HTTP status code, and any errors.
+// It uses DefaultHTTPClient and the standard logger.
+//
+// Deprecated: WebGet is a general HTTP helper that doesn't belong in this package's API; use net/http.
+func WebGet(url string) (string, int, error) {
+	body, statusCode, err := NewClient("").get(context.Background(), url)
+	if err != nil {
+		return "Error accessing URL", 0, err
+	}
+	return string(body), statusCode, nil
+}
@@ -0,0 +1,29 @@
+package dreamhostapi
+
+import "encoding/json"
+
+// A Decoder unmarshals a response body from the Dreamhost API into v.
+type Decoder interface {
+	Decode(data []byte, v any) error
+}
+
+// DecoderFunc adapts a function with the signature of json.Unmarshal to a Decoder,
+// so a faster JSON library can be plugged in with DecoderFunc(otherjson.Unmarshal).
+type DecoderFunc func(data []byte, v any) error
+
+func (f DecoderFunc) Decode(data []byte, v any) error {
+	return f(data, v)
+}
+
+// JSONDecoder is the Decoder new Clients start with. It defaults to encoding/json.
+var JSONDecoder Decoder = DecoderFunc(json.Unmarshal)
+
+// decode unmarshals a response body from the Dreamhost API with the Client's Decoder,
+// converting it to JSON first if the Client uses another format.
+func (c *Client) decode(response string, v any) error {
+	body, err := c.toJSON(response)
+	if err != nil {
+		return err
+	}
+	return c.decoder.Decode(body, v)
+}
@@ -0,0 +1,99 @@
+package dreamhostapi
+
+// equalOptions holds the options of DnsRecord.Equal and Diff.
+type equalOptions struct {
+	ignoreComment bool
+	ignoreAccount bool
+}
+
+// An EqualOption relaxes the comparison DnsRecord.Equal and Diff make.
+type EqualOption func(*equalOptions)
+
+// IgnoreComment makes the comparison ignore the records' comments.
+func IgnoreComment() EqualOption {
+	return func(o *equalOptions) {
+		o.ignoreComment = true
+	}
+}
+
+// IgnoreAccount makes the comparison ignore which account the records belong to.
+func IgnoreAccount() EqualOption {
+	return func(o *equalOptions) {
+		o.ignoreAccount = true
+	}
+}
+
+// Equal reports whether r and other are the same record: the same name and zone, ignoring case and any trailing dot,
+// and the same type, value, editability, comment, and account, unless opts say to ignore some of them.
+func (r DnsRecord) Equal(other DnsRecord, opts ...EqualOption) bool {
+	var o equalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return r.key() == other.key() &&
+		NormalizeName(r.Zone) == NormalizeName(other.Zone) &&
+		r.Editable == other.Editable &&
+		(o.ignoreComment || r.Comment == other.Comment) &&
+		(o.ignoreAccount || r.AccountId == other.AccountId)
+}
+
+// recordKey identifies a record by name, type, and value, the three things dns-remove_record needs.
+type recordKey struct {
+	name       string
+	recordType RecordType
+	value      string
+}
+
+// key returns the record's recordKey, with the name normalized.
+func (r DnsRecord) key() recordKey {
+	return recordKey{name: NormalizeName(r.Record), recordType: r.ZoneType, value: r.Value}
+}
+
+// A RecordChange is a record that exists in both listings a Diff compares, but with other differences, eg in its comment.
+type RecordChange struct {
+	Old DnsRecord
+	New DnsRecord
+}
+
+// A ChangeSet is the difference between two listings of records.
+type ChangeSet struct {
+	Added   []DnsRecord    // records only in the new listing, in its order
+	Removed []DnsRecord    // records only in the old listing, in its order
+	Changed []RecordChange // records in both with the same name, type, and value that are not Equal
+}
+
+// Empty reports whether the ChangeSet has no changes.
+func (cs ChangeSet) Empty() bool {
+	return len(cs.Added) == 0 && len(cs.Removed) == 0 && len(cs.Changed) == 0
+}
+
+// Diff returns what changed between the old and new listings.
+// Records are matched by name, type, and value; matched records that are not Equal under opts are reported as Changed.
+// Duplicate records are matched one to one, so an extra copy shows up as Added or Removed.
+func Diff(old DnsRecords, new DnsRecords, opts ...EqualOption) ChangeSet {
+	var cs ChangeSet
+	unmatched := make(map[recordKey][]int)
+	for i, record := range old.Data {
+		unmatched[record.key()] = append(unmatched[record.key()], i)
+	}
+	matched := make([]bool, len(old.Data))
+	for _, record := range new.Data {
+		candidates := unmatched[record.key()]
+		if len(candidates) == 0 {
+			cs.Added = append(cs.Added, record)
+			continue
+		}
+		i := candidates[0]
+		unmatched[record.key()] = candidates[1:]
+		matched[i] = true
+		if !old.Data[i].Equal(record, opts...) {
+			cs.Changed = append(cs.Changed, RecordChange{Old: old.Data[i], New: record})
+		}
+	}
+	for i, record := range old.Data {
+		if !matched[i] {
+			cs.Removed = append(cs.Removed, record)
+		}
+	}
+	return cs
+}
@@ -0,0 +1,265 @@
+// Package dreamhostapi contains functions for interacting with the Dreamhost API.
+//
+// Create a Client with NewClient and call its methods, each of which takes a context.
+// Errors the API reports are returned as *APIError; the package-level functions of v2 remain as deprecated shortcuts.
+package dreamhostapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// dnsRecords holds an array of DnsRecord structs returned by the Dreamhost API
+type DnsRecords struct {
+	Data   []DnsRecord `json:"data"`
+	Result string      `json:"result"`
+}
+
+// DnsRecord is a DNS Record on Dreamhost
+type DnsRecord struct {
+	Record    string     // the URL
+	Zone      string     // This is the base of the URL. If Record is www.google.com, Zone is google.com
+	Value     string     // this is what the zone points to - usually IP address
+	Editable  bool       // whether the record can be changed through the API; it comes back as the string 0 or 1
+	ZoneType  RecordType `json:"type"` // the record type, eg A, CNAME, or TXT
+	Comment   string     // comment that can be added to a record
+	AccountId string     `json:"account_id"` // the account associated with this record
+
+	Extra map[string]string // any other fields the API sent, by their JSON name, if listed with ExtraFields, so nothing it adds later is lost
+}
+
+func (r DnsRecord) String() string {
+	return fmt.Sprintf("\nRecord (URL): %s in Zone: %s. \nIt points to %s. \nZone Type: %s \nIs it Editable? %t. \nIt Belongs to: %s. \nComment: %s\n", r.Record, r.Zone, r.Value, r.ZoneType, r.Editable, r.AccountId, r.Comment)
+}
+
+// A CommandResult holds the JSON result from adding or removing a record using the Dreamhost API.
+// Its Data is a string representing what happened, eg "record_added". If Result is "error", Data is empty and the error code is in the returned *APIError.
+type CommandResult = Response[string]
+
+// submitDreamhostCommand returns the response from the Dreamhost API as JSON as well as any errors.
+// In the case of any errors (eg web access) it returns an empty string.
+// The API key is redacted from any URL in the returned error.
+// Commands in the registry are checked locally first and a ValidationError is returned without calling the API if they are malformed.
+// Commands that change the account are given a random unique_id unless they already have one, and it is kept across retries,
+// so the API won't apply the same command twice.
+// The generated id only covers those retries within one call: a caller that retries a call itself, eg after a timeout,
+// gets a new id and should set its own with WithUniqueID.
+// If the scope pre-flight is on, commands that change the account and that the API key may not run fail with an InsufficientScopeError.
+// If the Client has an account scope, commands that change the account are sent for it, and fail with an AccountScopeError if they name another one.
+// When the API rate limits the request, it waits and retries as the Client's BackoffStrategy decides, returning ErrRateLimited if that gives up.
+// The wait holds back every other command of the Client too, since they would be rate limited as well.
+// Cancelling ctx aborts both the request and any wait, returning ctx.Err().
+// The command map is essentially a map in which the keys correspond to the items that can be edited by the API.
+// As of now, all [Dreamhost DNS commands] are implemented.
+//
+// [Dreamhost DNS commands]: https://help.dreamhost.com/hc/en-us/articles/217555707-DNS-API-commands
+func (c *Client) submitDreamhostCommand(ctx context.Context, command map[string]string) (string, error) {
+	response, err := c.openCommand(ctx, command)
+	if err != nil {
+		return "", err
+	}
+	body, err := c.readBody(response)
+	c.responded(command["cmd"], response.StatusCode, body, err)
+	return string(body), err
+}
+
+// openCommand sends command as submitDreamhostCommand does, retrying it while it is rate limited,
+// and returns the final response with its body unread, which the caller must close.
+// The bodies of rate-limited responses are read and passed to the Client's response hooks here.
+func (c *Client) openCommand(ctx context.Context, command map[string]string) (*http.Response, error) {
+	if err := checkCommand(command); err != nil {
+		return nil, err
+	}
+	if c.checkScopes {
+		if err := c.checkScope(ctx, command["cmd"]); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.checkAccount(command); err != nil {
+		return nil, err
+	}
+	fullURL, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API base URL: %w", err)
+	}
+	apiKey, err := c.key(ctx)
+	if err != nil {
+		return nil, err
+	}
+	parameters := url.Values{}
+	parameters.Set("key", apiKey)
+	for key, value := range command {
+		parameters.Add(key, value)
+	}
+	parameters.Add("format", string(c.format))
+	if spec, ok := LookupCommand(command["cmd"]); ok && spec.Mutating {
+		if command["unique_id"] == "" {
+			uniqueID, err := NewUniqueID()
+			if err != nil {
+				return nil, err
+			}
+			parameters.Set("unique_id", uniqueID)
+		}
+		if c.accountScope != "" {
+			parameters.Set("account", c.accountScope)
+		}
+		// The record cache is dropped both now and once the API has answered,
+		// so a listing fetched while the command is in flight isn't cached.
+		c.invalidateRecords()
+		defer c.invalidateRecords()
+	}
+	for attempt := 1; ; attempt++ {
+		if err := c.limiter.wait(ctx, c.clock); err != nil {
+			return nil, err
+		}
+		response, err := c.open(ctx, fullURL, parameters)
+		if err != nil { // there was an error at the web level.
+			c.responded(command["cmd"], 0, nil, err)
+			return nil, err
+		}
+		if response.StatusCode != http.StatusTooManyRequests {
+			return response, nil
+		}
+		body, err := c.readBody(response)
+		c.responded(command["cmd"], response.StatusCode, body, err)
+		delay := c.backoff.Backoff(attempt)
+		if delay < 0 {
+			return nil, ErrRateLimited
+		}
+		c.logger.Printf("Rate limit hit. Pausing execution for %s.\n", delay)
+		c.limiter.pause(c.clock.Now().Add(delay))
+	}
+}
+
+// SubmitCommand runs any Dreamhost API command, including ones this package doesn't wrap yet, and returns its decoded envelope.
+// The key, cmd, and format parameters are filled in; params holds the rest.
+// If the API reports an error, the envelope is returned along with an *APIError holding the error code from its data field.
+func (c *Client) SubmitCommand(ctx context.Context, cmd string, params map[string]string) (Envelope, error) {
+	command := map[string]string{"cmd": cmd}
+	for key, value := range params {
+		if key != "cmd" {
+			command[key] = value
+		}
+	}
+	return submitCommand[json.RawMessage](ctx, c, command)
+}
+
+// GetDNSRecords returns a DnsRecords struct containing all of the DNS records on the account and any errors.
+// It returns an empty struct in the case of any errors in the web-layer, JSON demarshalling, or API non-success result,
+// and in the last case the error is an *APIError holding the API's error code, eg for a bad apiKey.
+// opts can ask for the records in a stable order, and with the fields DnsRecord has no field for; see SortedRecords and ExtraFields.
+// If the Client has an account scope, only the records of that account are returned; see WithAccountScope.
+func (c *Client) GetDNSRecords(ctx context.Context, opts ...ListOption) (DnsRecords, error) {
+	command := map[string]string{"cmd": "dns-list_records"}
+	var records DnsRecords
+	if newListOptions(opts).extra {
+		response, err := submitCommand[json.RawMessage](ctx, c, command)
+		if err != nil {
+			return DnsRecords{}, err
+		}
+		records.Result = response.Result
+		if records.Data, err = c.decodeWithExtra(response.Data); err != nil {
+			return DnsRecords{}, err
+		}
+	} else {
+		response, err := submitCommand[[]DnsRecord](ctx, c, command)
+		if err != nil {
+			return DnsRecords{}, err
+		}
+		records = DnsRecords{Data: response.Data, Result: response.Result}
+	}
+	if c.accountScope != "" {
+		records = records.ByAccount(c.accountScope)
+	}
+	return applyListOptions(records, opts), nil
+}
+
+// AddRecord returns a CommandResult after using the Dreamhost API to add value to record and any errors.
+// The record is an A record unless WithType says otherwise.
+// If the API does not succeed, the result's Result is "error" and its Data is empty; the error code is only in the returned *APIError.
+// If the hosted-zone pre-flight is on, adding a record to a zone that isn't hosted on the account returns a ZoneNotHostedError without calling dns-add_record.
+// If the CNAME pre-flight is set to refuse, an add that breaks the CNAME coexistence rule returns a CNAMEConflictError; see WithCNAMECheck.
+func (c *Client) AddRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	return c.changeRecord(ctx, "add", record, value, opts...)
+}
+
+// RemoveRecord returns a CommandResult after using the Dreamhost API to remove value from record and any errors.
+// The record is an A record unless WithType says otherwise.
+// If the API does not succeed, the result's Result is "error" and its Data is empty; the error code is only in the returned *APIError.
+func (c *Client) RemoveRecord(ctx context.Context, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	return c.changeRecord(ctx, "del", record, value, opts...)
+}
+
+// changeRecord does the work of AddRecord and RemoveRecord. command is "add" or "del".
+func (c *Client) changeRecord(ctx context.Context, command string, record string, value string, opts ...RecordOption) (CommandResult, error) {
+	var updateResult CommandResult
+	commandOptions, err := recordCommand(command, record, value, newRecordOptions(opts))
+	if err != nil {
+		return updateResult, err
+	}
+	if command == "add" {
+		if err := c.checkAdd(ctx, commandOptions["record"], RecordType(commandOptions["type"])); err != nil {
+			return updateResult, err
+		}
+	}
+	return submitCommand[string](ctx, c, commandOptions)
+}
+
+// checkAdd runs the pre-flights the Client has turned on for adding a record named name of type recordType.
+func (c *Client) checkAdd(ctx context.Context, name string, recordType RecordType) error {
+	if c.checkZones {
+		if _, err := c.CheckZoneHosted(ctx, name); err != nil {
+			return err
+		}
+	}
+	return c.checkCNAME(ctx, name, recordType)
+}
+
+// recordCommand returns the dns-add_record or dns-remove_record command for changeRecord's command of "add" or "del".
+// The record name is normalized with NormalizeName, and any host name in the value is converted to punycode with ValueToASCII.
+func recordCommand(command string, record string, value string, options recordOptions) (map[string]string, error) {
+	record = NormalizeName(record)
+	value, err := ValueToASCII(options.recordType, value)
+	if err != nil {
+		return nil, err
+	}
+	var commandOptions map[string]string
+	switch command {
+	case "add":
+		commandOptions = map[string]string{"cmd": "dns-add_record", "record": record, "type": string(options.recordType), "value": value}
+	case "del":
+		commandOptions = map[string]string{"cmd": "dns-remove_record", "record": record, "type": string(options.recordType), "value": value}
+	default:
+		return nil, fmt.Errorf("unknown zone file command %q", command)
+	}
+	if options.comment != "" {
+		commandOptions["comment"] = options.comment
+	}
+	if options.account != "" {
+		commandOptions["account"] = options.account
+	}
+	if options.uniqueID != "" {
+		commandOptions["unique_id"] = options.uniqueID
+	}
+	return commandOptions, nil
+}
+
+// UpdateDNSRecord returns a CommandResult after using the Dreamhost API to first add the new IP address and, if successful, deleting the old one.
+// If adding a record does not succeed, either through underlying error (web, JSON unmarshalling) or because the API was not successful, it will not continue to the deletion.
+// opts apply to both the add and the removal, except that an id set with WithUniqueID is suffixed with -add and -remove,
+// since the API refuses a second command with the same id.
+func (c *Client) UpdateDNSRecord(ctx context.Context, domain string, currentIP string, newIPAddress string, opts ...RecordOption) (CommandResult, CommandResult, error) {
+	var empty CommandResult
+	resultOfAdd, err := c.AddRecord(ctx, domain, newIPAddress, withSubID(opts, "add")...)
+	if err != nil {
+		return resultOfAdd, empty, err
+	}
+	resultOfDelete, err := c.RemoveRecord(ctx, domain, currentIP, withSubID(opts, "remove")...)
+	if err != nil {
+		return resultOfAdd, resultOfDelete, err
+	}
+	return resultOfAdd, resultOfDelete, err
+}
@@ -0,0 +1,81 @@
+package dreamhostapi
+
+import "context"
+
+// EnsureRecord adds a record of recordType with value to record unless an identical one already exists, comparing host names in values as normalized by NormalizeName.
+// It reports whether a record was added. A non-success result from the API is returned as an error.
+func (c *Client) EnsureRecord(ctx context.Context, record string, recordType RecordType, value string, comment string) (bool, error) {
+	records, err := c.GetDNSRecords(ctx)
+	if err != nil {
+		return false, err
+	}
+	if records.contains(record, recordType, value) {
+		return false, nil
+	}
+	_, err = c.AddRecord(ctx, record, value, WithType(recordType), WithComment(comment))
+	return err == nil, err
+}
+
+// EnsureOnly makes values the exact set of recordType records for name and returns the values it added and removed.
+// Missing values are added, in parallel on the Client's pool, before extraneous ones are deleted, so the name never goes without a record.
+// If an add fails, nothing is deleted and the errors are returned along with the values that were added.
+// Values are compared with host names normalized, as AddRecord sends them. An empty values is a ValidationError, not a request to remove every record.
+func (c *Client) EnsureOnly(ctx context.Context, name string, recordType RecordType, values []string, comment string) ([]string, []string, error) {
+	if len(values) == 0 {
+		return nil, nil, &ValidationError{Field: "values", Reason: "is empty; remove the records with RemoveRecord instead"}
+	}
+	records, err := c.GetDNSRecords(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	wanted := make(map[string]bool)
+	var missing []string
+	var tasks []Task
+	for _, value := range values {
+		key := normalizeValue(recordType, value)
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+		if records.contains(name, recordType, value) {
+			continue
+		}
+		missing = append(missing, value)
+		tasks = append(tasks, Task{Name: value, Run: func(ctx context.Context) error {
+			_, err := c.AddRecord(ctx, name, value, WithType(recordType), WithComment(comment))
+			return err
+		}})
+	}
+	report := c.pool().Run(ctx, tasks)
+	var added []string
+	for i, result := range report.Results {
+		if result.Err == nil {
+			added = append(added, missing[i])
+		}
+	}
+	if err := report.Err(); err != nil {
+		return added, nil, err
+	}
+	var extraneous []DnsRecord
+	for _, record := range records.Data {
+		if sameName(record.Record, name) && record.ZoneType == recordType && !wanted[normalizeValue(recordType, record.Value)] {
+			extraneous = append(extraneous, record)
+		}
+	}
+	deleted, err := c.removeRecords(ctx, extraneous)
+	removed := make([]string, 0, len(deleted))
+	for _, record := range deleted {
+		removed = append(removed, record.Value)
+	}
+	return added, removed, err
+}
+
+// contains reports whether there is a record with this name, type, and value.
+func (records DnsRecords) contains(record string, recordType RecordType, value string) bool {
+	for _, existing := range records.Data {
+		if sameName(existing.Record, record) && existing.ZoneType == recordType && sameValue(recordType, existing.Value, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package dreamhostapi
+
+import "fmt"
+
+// An APIError is an error the Dreamhost API reported in its response, eg a bad API key or a record that already exists.
+type APIError struct {
+	Command string // the command that failed, eg dns-add_record
+	Code    string // the error code from the response's data field, eg invalid_api_key or record_already_exists_not_editable
+	Reason  string // the longer explanation some errors come with, may be empty
+}
+
+func (e *APIError) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("%s: %s", e.Command, e.Code)
+	}
+	return fmt.Sprintf("%s: %s (%s)", e.Command, e.Code, e.Reason)
+}
+
+// Is reports whether target is an *APIError with the same code, so errors.Is(err, &APIError{Code: "no_record"}) matches whatever command failed.
+// A target with a Command only matches errors from that command.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	return (t.Code == "" || t.Code == e.Code) && (t.Command == "" || t.Command == e.Command)
+}
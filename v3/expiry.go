@@ -0,0 +1,93 @@
+package dreamhostapi
+
+import (
+	"context"
+	"time"
+)
+
+// ExpiresKey is the comment metadata key that holds a record's expiry timestamp.
+const ExpiresKey = "expires"
+
+// CreatedKey is the comment metadata key that holds the time a record was created.
+const CreatedKey = "created"
+
+// ExpiringComment returns comment tagged with an expiry timestamp that Expire will honor.
+// Pass the result to WithComment when adding a record to make it temporary.
+func ExpiringComment(comment string, expires time.Time) string {
+	return MergeMetadata(comment, Metadata{ExpiresKey: expires.UTC().Format(time.RFC3339)})
+}
+
+// CreatedComment returns comment tagged with the time the record was created, so its age can be judged later.
+func CreatedComment(comment string, created time.Time) string {
+	return MergeMetadata(comment, Metadata{CreatedKey: created.UTC().Format(time.RFC3339)})
+}
+
+// Created returns the creation timestamp stored in the record's comment and whether there was a valid one.
+func (r DnsRecord) Created() (time.Time, bool) {
+	return r.metadataTime(CreatedKey)
+}
+
+// Expiry returns the expiry timestamp stored in the record's comment and whether there was a valid one.
+func (r DnsRecord) Expiry() (time.Time, bool) {
+	return r.metadataTime(ExpiresKey)
+}
+
+// metadataTime parses the RFC 3339 timestamp stored under key in the record's comment.
+func (r DnsRecord) metadataTime(key string) (time.Time, bool) {
+	value, ok := r.Metadata()[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return timestamp, true
+}
+
+// Expired returns the records whose expiry timestamp is at or before now.
+// Records without an expiry timestamp never expire.
+func (records DnsRecords) Expired(now time.Time) DnsRecords {
+	expired := DnsRecords{Result: records.Result}
+	for _, record := range records.Data {
+		if expires, ok := record.Expiry(); ok && !expires.After(now) {
+			expired.Data = append(expired.Data, record)
+		}
+	}
+	return expired
+}
+
+// Expire deletes every editable record whose expiry timestamp has passed and returns the records it removed.
+// It keeps going when a deletion fails and returns all of the failures joined together.
+func (c *Client) Expire(ctx context.Context) ([]DnsRecord, error) {
+	records, err := c.GetDNSRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return c.removeRecords(ctx, records.Expired(c.clock.Now()).Data)
+}
+
+// removeRecords deletes each editable record, running up to the Client's concurrency at once,
+// and returns the ones that were removed along with any failures.
+func (c *Client) removeRecords(ctx context.Context, records []DnsRecord) ([]DnsRecord, error) {
+	var editable []DnsRecord
+	var tasks []Task
+	for _, record := range records {
+		if !record.Editable {
+			continue
+		}
+		editable = append(editable, record)
+		tasks = append(tasks, Task{Name: record.Record + " " + string(record.ZoneType) + " " + record.Value, Run: func(ctx context.Context) error {
+			_, err := c.RemoveRecord(ctx, record.Record, record.Value, WithType(record.ZoneType))
+			return err
+		}})
+	}
+	report := c.pool().Run(ctx, tasks)
+	var removed []DnsRecord
+	for i, result := range report.Results {
+		if result.Err == nil {
+			removed = append(removed, editable[i])
+		}
+	}
+	return removed, report.Err()
+}
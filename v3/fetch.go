@@ -0,0 +1,122 @@
+package dreamhostapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// get fetches target with the Client's HTTP client, giving up when ctx is done, and returns the body, the HTTP status code, and any errors.
+func (c *Client) get(ctx context.Context, target string) ([]byte, int, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, 0, redactKey(err)
+	}
+	return c.do(request)
+}
+
+// newPost returns a request that POSTs form to target as the request body.
+func newPost(ctx context.Context, target string, form url.Values) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return request, nil
+}
+
+// do sends request with the Client's User-Agent header through its middleware and returns the body, the HTTP status code, and any errors.
+// Responses with an unsuccessful status code are logged to the Client's logger, but are not errors.
+// The API key is redacted from any URL in the returned error.
+func (c *Client) do(request *http.Request) ([]byte, int, error) {
+	response, err := c.roundTrip(request)
+	if err != nil {
+		return nil, 0, err
+	}
+	body, err := c.readBody(response)
+	return body, response.StatusCode, err
+}
+
+// roundTrip sends request with the Client's User-Agent header through its middleware and returns the response with its body unread.
+// The API key is redacted from any URL in the returned error.
+func (c *Client) roundTrip(request *http.Request) (*http.Response, error) {
+	request.Header.Set("User-Agent", c.userAgent)
+	response, err := c.doer().Do(request)
+	if err != nil {
+		return nil, redactKey(err)
+	}
+	return response, nil
+}
+
+// readBody reads and closes the body of response.
+// Responses with an unsuccessful status code are logged to the Client's logger, but are not errors.
+func (c *Client) readBody(response *http.Response) ([]byte, error) {
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if response.StatusCode > 299 {
+		c.logger.Printf("Response failed with status code: %d and \nbody: %s\n", response.StatusCode, body)
+	}
+	return body, nil
+}
+
+// open sends parameters to endpoint, in a POST form body if the Client is set to use POST and otherwise in the query string alongside any parameters endpoint already has,
+// and returns the response with its body unread, which the caller must close.
+// If the endpoint refuses the POST with 405 Method Not Allowed, the command is sent again with GET.
+func (c *Client) open(ctx context.Context, endpoint *url.URL, parameters url.Values) (*http.Response, error) {
+	if c.usePOST {
+		request, err := newPost(ctx, endpoint.String(), parameters)
+		if err != nil {
+			return nil, err
+		}
+		response, err := c.roundTrip(request)
+		if err != nil || response.StatusCode != http.StatusMethodNotAllowed {
+			return response, err
+		}
+		response.Body.Close()
+		c.logger.Println("POST not allowed by the API endpoint, falling back to GET.")
+	}
+	fullURL := *endpoint
+	query := fullURL.Query()
+	for key, values := range parameters {
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	fullURL.RawQuery = query.Encode()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL.String(), nil)
+	if err != nil {
+		return nil, redactKey(err)
+	}
+	return c.roundTrip(request)
+}
+
+// redactKey replaces the value of the key query parameter in any URL carried by err, so the API key doesn't end up in logs or error messages.
+func redactKey(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
+	}
+	return err
+}
+
+// redactURL returns rawURL with the value of its key query parameter replaced by REDACTED.
+func redactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := parsed.Query()
+	if !query.Has("key") {
+		return rawURL
+	}
+	query.Set("key", "REDACTED")
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,106 @@
+package dreamhostapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A Format is a response format the Dreamhost API can send, set with WithFormat.
+// Whatever the format, responses are decoded into the same types, so it only matters to tooling that sees the raw bodies,
+// eg through a ResponseHook or Middleware.
+type Format string
+
+const (
+	FormatJSON Format = "json" // the default
+	FormatTab  Format = "tab"  // the result on the first line, then a header line and tab-separated rows, or a single line of data
+	FormatYAML Format = "yaml" // a YAML document with result and data keys
+)
+
+// WithFormat sets the format the Client asks the API to respond in. It defaults to FormatJSON.
+// Tab and YAML responses are converted to the JSON form before the Client's Decoder sees them,
+// with every value as a string as the API sends them in JSON.
+func WithFormat(format Format) Option {
+	return func(c *Client) {
+		c.format = format
+	}
+}
+
+// toJSON returns a response body in the Client's format as the equivalent JSON body.
+func (c *Client) toJSON(body string) ([]byte, error) {
+	switch c.format {
+	case "", FormatJSON:
+		return []byte(body), nil
+	case FormatTab:
+		return json.Marshal(parseTab(body))
+	case FormatYAML:
+		var document yaml.Node
+		if err := yaml.Unmarshal([]byte(body), &document); err != nil {
+			return nil, fmt.Errorf("invalid YAML response: %w", err)
+		}
+		return json.Marshal(yamlValue(&document))
+	}
+	return nil, fmt.Errorf("unknown response format %q", c.format)
+}
+
+// parseTab returns a tab format response body as an Envelope-shaped value.
+// A body with a header line gives a list of rows keyed by the header's column names, and any other a string.
+func parseTab(body string) map[string]any {
+	lines := strings.Split(strings.TrimRight(body, "\r\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSuffix(lines[i], "\r")
+	}
+	response := map[string]any{"result": strings.TrimSpace(lines[0])}
+	rest := lines[1:]
+	if len(rest) == 0 || (len(rest) == 1 && !strings.Contains(rest[0], "\t")) {
+		response["data"] = strings.TrimSpace(strings.Join(rest, ""))
+		return response
+	}
+	header := strings.Split(rest[0], "\t")
+	rows := make([]map[string]string, 0, len(rest)-1)
+	for _, line := range rest[1:] {
+		if line == "" {
+			continue
+		}
+		row := make(map[string]string, len(header))
+		for i, cell := range strings.Split(line, "\t") {
+			if i < len(header) {
+				row[header[i]] = cell
+			}
+		}
+		rows = append(rows, row)
+	}
+	response["data"] = rows
+	return response
+}
+
+// yamlValue returns the value of a YAML node as maps, slices, and strings.
+func yamlValue(node *yaml.Node) any {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return nil
+		}
+		return yamlValue(node.Content[0])
+	case yaml.AliasNode:
+		return yamlValue(node.Alias)
+	case yaml.MappingNode:
+		mapping := make(map[string]any, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			mapping[node.Content[i].Value] = yamlValue(node.Content[i+1])
+		}
+		return mapping
+	case yaml.SequenceNode:
+		sequence := make([]any, 0, len(node.Content))
+		for _, item := range node.Content {
+			sequence = append(sequence, yamlValue(item))
+		}
+		return sequence
+	}
+	if node.Tag == "!!null" {
+		return ""
+	}
+	return node.Value
+}
@@ -0,0 +1,10 @@
+module github.com/djotaku/dreamhostapi/v3
+
+go 1.23.0
+
+require (
+	golang.org/x/net v0.38.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/text v0.23.0 // indirect
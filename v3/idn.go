@@ -0,0 +1,117 @@
+package dreamhostapi
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// ToASCII returns name with every internationalized label converted to punycode, eg bücher.example to xn--bcher-kva.example.
+// Labels that are already ASCII, including wildcards and underscore labels such as _dmarc, are left alone.
+func ToASCII(name string) (string, error) {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		converted, err := idna.Lookup.ToASCII(label)
+		if err != nil {
+			return name, &ValidationError{Field: "name", Value: name, Reason: "has an invalid internationalized label: " + err.Error()}
+		}
+		labels[i] = converted
+	}
+	return strings.Join(labels, "."), nil
+}
+
+// ToUnicode returns name with every punycode label converted back to Unicode, eg xn--bcher-kva.example to bücher.example.
+// Labels that don't decode are left as they are.
+func ToUnicode(name string) string {
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		if !strings.HasPrefix(strings.ToLower(label), "xn--") {
+			continue
+		}
+		if converted, err := idna.Lookup.ToUnicode(label); err == nil {
+			labels[i] = converted
+		}
+	}
+	return strings.Join(labels, ".")
+}
+
+// UnicodeName returns the record's name with any punycode labels shown in Unicode.
+func (r DnsRecord) UnicodeName() string {
+	return ToUnicode(r.Record)
+}
+
+// UnicodeNames makes GetDNSRecords return record and zone names, and the host names in the values of CNAME, NS, MX, SRV, and NAPTR records,
+// with punycode labels converted to Unicode.
+// Without it, listings hold names as the API stores them, in punycode, which is what ListDomains and the zone pre-flight compare against.
+// The records can still be passed back to AddRecord and RemoveRecord, which convert names and values to punycode again.
+func UnicodeNames() ListOption {
+	return func(o *listOptions) {
+		o.unicode = true
+	}
+}
+
+// hostField returns where the host name is in value, a value of a record of type recordType, as the byte offsets of its start and end.
+// It is the whole value for CNAME and NS records and the last field for MX, SRV, and NAPTR records, whose fields are split as by splitNAPTR.
+// ok is false for types whose values hold no host name, and for NAPTR values that don't split into their six fields.
+func hostField(recordType RecordType, value string) (start int, end int, ok bool) {
+	switch recordType {
+	case CNAME, NS:
+		return 0, len(value), true
+	case NAPTR:
+		if fields, err := splitNAPTR(value); err != nil || len(fields) != 6 {
+			return 0, 0, false
+		}
+	case MX, SRV:
+	default:
+		return 0, 0, false
+	}
+	end = len(strings.TrimRight(value, " \t"))
+	start = strings.LastIndexAny(value[:end], " \t") + 1
+	if start == end || value[end-1] == '"' {
+		return 0, 0, false
+	}
+	return start, end, true
+}
+
+// convertValue returns value, of a record of type recordType, with its host name converted by convert.
+// The rest of the value, including its spacing and any quoted NAPTR fields, is left as it is, as are values of other types.
+func convertValue(recordType RecordType, value string, convert func(string) (string, error)) (string, error) {
+	start, end, ok := hostField(recordType, value)
+	if !ok {
+		return value, nil
+	}
+	host, err := convert(value[start:end])
+	if err != nil {
+		return value, err
+	}
+	return value[:start] + host + value[end:], nil
+}
+
+// ValueToASCII returns value, of a record of type recordType, with the host name it holds converted to punycode as by ToASCII.
+// Only CNAME, NS, MX, SRV, and NAPTR values hold host names; others are returned as they are.
+// AddRecord and RemoveRecord convert values with it.
+func ValueToASCII(recordType RecordType, value string) (string, error) {
+	return convertValue(recordType, value, ToASCII)
+}
+
+// ValueToUnicode returns value, of a record of type recordType, with the host name it holds converted to Unicode as by ToUnicode.
+func ValueToUnicode(recordType RecordType, value string) string {
+	converted, _ := convertValue(recordType, value, func(name string) (string, error) {
+		return ToUnicode(name), nil
+	})
+	return converted
+}
+
+// isASCII reports whether s contains only ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
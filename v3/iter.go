@@ -0,0 +1,52 @@
+package dreamhostapi
+
+import (
+	"iter"
+	"strings"
+)
+
+// All returns an iterator over the records, in order.
+func (records DnsRecords) All() iter.Seq[DnsRecord] {
+	return func(yield func(DnsRecord) bool) {
+		for _, record := range records.Data {
+			if !yield(record) {
+				return
+			}
+		}
+	}
+}
+
+// Where returns an iterator over the records for which keep returns true. Unlike Filter, it builds no new slice.
+func (records DnsRecords) Where(keep func(DnsRecord) bool) iter.Seq[DnsRecord] {
+	return func(yield func(DnsRecord) bool) {
+		for _, record := range records.Data {
+			if keep(record) && !yield(record) {
+				return
+			}
+		}
+	}
+}
+
+// InZone returns an iterator over the records in zone, ignoring case and any trailing dot.
+func (records DnsRecords) InZone(zone string) iter.Seq[DnsRecord] {
+	zone = strings.TrimSuffix(zone, ".")
+	return records.Where(func(record DnsRecord) bool {
+		return sameName(record.Zone, zone)
+	})
+}
+
+// OfType returns an iterator over the records of type recordType.
+func (records DnsRecords) OfType(recordType RecordType) iter.Seq[DnsRecord] {
+	return records.Where(func(record DnsRecord) bool {
+		return record.ZoneType == recordType
+	})
+}
+
+// CollectRecords gathers the records from seq into a DnsRecords struct.
+func CollectRecords(seq iter.Seq[DnsRecord]) DnsRecords {
+	records := DnsRecords{Result: "success"}
+	for record := range seq {
+		records.Data = append(records.Data, record)
+	}
+	return records
+}
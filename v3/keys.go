@@ -0,0 +1,124 @@
+package dreamhostapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIKeyEnv is the environment variable KeyFromEnv reads.
+const APIKeyEnv = "DREAMHOST_API_KEY"
+
+// ErrNoAPIKey is returned when a key source exists but holds no key.
+var ErrNoAPIKey = errors.New("no API key found")
+
+// A KeyProvider supplies the API key a Client sends with each command.
+// A Client with a KeyProvider asks it for the key before every command, so a key rotated in its source is picked up without a restart.
+type KeyProvider interface {
+	Key(ctx context.Context) (string, error)
+}
+
+// KeyProviderFunc adapts an ordinary function to a KeyProvider.
+type KeyProviderFunc func(ctx context.Context) (string, error)
+
+// Key calls f(ctx).
+func (f KeyProviderFunc) Key(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// KeyFromEnv returns the API key in the DREAMHOST_API_KEY environment variable and any errors.
+func KeyFromEnv() (string, error) {
+	return EnvKey(APIKeyEnv).Key(context.Background())
+}
+
+// KeyFromFile returns the API key stored in the file at path, with surrounding whitespace removed, and any errors.
+func KeyFromFile(path string) (string, error) {
+	return FileKey(path).Key(context.Background())
+}
+
+// EnvKey returns a KeyProvider that reads the API key from the environment variable name.
+func EnvKey(name string) KeyProvider {
+	return KeyProviderFunc(func(ctx context.Context) (string, error) {
+		key := strings.TrimSpace(os.Getenv(name))
+		if key == "" {
+			return "", fmt.Errorf("%w in environment variable %s", ErrNoAPIKey, name)
+		}
+		return key, nil
+	})
+}
+
+// FileKey returns a KeyProvider that reads the API key from the file at path, with surrounding whitespace removed.
+func FileKey(path string) KeyProvider {
+	return KeyProviderFunc(func(ctx context.Context) (string, error) {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		key := strings.TrimSpace(string(contents))
+		if key == "" {
+			return "", fmt.Errorf("%w in %s", ErrNoAPIKey, path)
+		}
+		return key, nil
+	})
+}
+
+// KeyringKey returns a KeyProvider that reads the API key from the operating system's keyring,
+// stored under service and account.
+// On Linux it uses secret-tool from libsecret, and on macOS the security command; other systems are not supported.
+func KeyringKey(service string, account string) KeyProvider {
+	return KeyProviderFunc(func(ctx context.Context) (string, error) {
+		var cmd *exec.Cmd
+		switch runtime.GOOS {
+		case "linux", "freebsd", "openbsd", "netbsd":
+			cmd = exec.CommandContext(ctx, "secret-tool", "lookup", "service", service, "account", account)
+		case "darwin":
+			cmd = exec.CommandContext(ctx, "security", "find-generic-password", "-s", service, "-a", account, "-w")
+		default:
+			return "", errors.New("keyring lookup is not supported on " + runtime.GOOS)
+		}
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("reading API key from keyring: %w", err)
+		}
+		key := strings.TrimSpace(string(output))
+		if key == "" {
+			return "", fmt.Errorf("%w in keyring for service %s and account %s", ErrNoAPIKey, service, account)
+		}
+		return key, nil
+	})
+}
+
+// CachedKey returns a KeyProvider that reuses the key from provider for ttl before asking it again,
+// for sources that are slow to read, such as the keyring.
+func CachedKey(provider KeyProvider, ttl time.Duration) KeyProvider {
+	var mu sync.Mutex
+	var key string
+	var fetched time.Time
+	return KeyProviderFunc(func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if key != "" && time.Since(fetched) < ttl {
+			return key, nil
+		}
+		fresh, err := provider.Key(ctx)
+		if err != nil {
+			return "", err
+		}
+		key, fetched = fresh, time.Now()
+		return key, nil
+	})
+}
+
+// key returns the API key for the next command: the KeyProvider's if the Client has one, otherwise the key it was created with.
+func (c *Client) key(ctx context.Context) (string, error) {
+	if c.keyProvider == nil {
+		return c.apiKey, nil
+	}
+	return c.keyProvider.Key(ctx)
+}
@@ -0,0 +1,81 @@
+package dreamhostapi
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// limiter paces the requests of a Client. Every goroutine using the Client waits on the same limiter,
+// so requests are spaced by the Client's rate limit and all of them hold off while the API is rate limiting.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration // the least time between the starts of two requests
+	next     time.Time     // the earliest time the next request may start
+	free     []time.Time   // slots before next given up by callers whose context was done, sorted, for later callers to take
+}
+
+// wait blocks until the next request may start, returning early with ctx.Err() if ctx is done first.
+// A caller that returns early gives its slot back, so it doesn't hold back the callers queued after it.
+func (l *limiter) wait(ctx context.Context, clock Clock) error {
+	l.mu.Lock()
+	now := clock.Now()
+	start := l.reserve(now)
+	l.mu.Unlock()
+	if delay := start.Sub(now); delay > 0 {
+		if err := clock.Sleep(ctx, delay); err != nil {
+			l.release(start)
+			return err
+		}
+	}
+	return nil
+}
+
+// reserve takes the earliest slot at or after now, either one given up by another caller or the next one, and returns its start.
+// l.mu must be held.
+func (l *limiter) reserve(now time.Time) time.Time {
+	for len(l.free) > 0 && l.free[0].Before(now) {
+		l.free = l.free[1:]
+	}
+	if len(l.free) > 0 {
+		start := l.free[0]
+		l.free = l.free[1:]
+		return start
+	}
+	start := now
+	if l.next.After(start) {
+		start = l.next
+	}
+	l.next = start.Add(l.interval)
+	return start
+}
+
+// release gives back the slot starting at start.
+// The last slot handed out moves next back, along with any free slots then at the end; any other slot is kept for reserve.
+func (l *limiter) release(start time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !start.Add(l.interval).Equal(l.next) {
+		i, _ := slices.BinarySearchFunc(l.free, start, time.Time.Compare)
+		l.free = slices.Insert(l.free, i, start)
+		return
+	}
+	l.next = start
+	for len(l.free) > 0 && l.free[len(l.free)-1].Add(l.interval).Equal(l.next) {
+		l.next = l.free[len(l.free)-1]
+		l.free = l.free[:len(l.free)-1]
+	}
+}
+
+// pause holds back every request until at least until.
+func (l *limiter) pause(until time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until.After(l.next) {
+		l.next = until
+	}
+	l.free = slices.DeleteFunc(l.free, func(slot time.Time) bool {
+		return slot.Before(until)
+	})
+}
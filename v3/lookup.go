@@ -0,0 +1,110 @@
+package dreamhostapi
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// FindRecord returns the first DNS record on the account named name, of type recordType, whether there is one, and any errors.
+// name is normalized with NormalizeName, and an empty recordType matches every type.
+// The listing is reused for as long as WithRecordCache allows.
+func (c *Client) FindRecord(ctx context.Context, name string, recordType RecordType) (DnsRecord, bool, error) {
+	records, err := c.listRecords(ctx)
+	if err != nil {
+		return DnsRecord{}, false, err
+	}
+	record, ok := records.Find(NormalizeName(name), recordType)
+	return record, ok, nil
+}
+
+// RecordExists reports whether the account has a DNS record named name, of type recordType, with value, and any errors,
+// eg so a dynamic DNS client can tell whether its IP address is already set.
+// name is normalized with NormalizeName. The listing is reused for as long as WithRecordCache allows.
+func (c *Client) RecordExists(ctx context.Context, name string, recordType RecordType, value string) (bool, error) {
+	records, err := c.listRecords(ctx)
+	if err != nil {
+		return false, err
+	}
+	return records.contains(NormalizeName(name), recordType, value), nil
+}
+
+// GetRecordValues returns the values of the DNS records on the account named name, of type recordType, and any errors,
+// eg the current A addresses of home.example.com. It returns no values, and no error, if there are no such records.
+// name is normalized with NormalizeName. The listing is reused for as long as WithRecordCache allows.
+func (c *Client) GetRecordValues(ctx context.Context, name string, recordType RecordType) ([]string, error) {
+	records, err := c.listRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name = NormalizeName(name)
+	var values []string
+	for _, record := range records.Data {
+		if record.Matches(name, recordType) {
+			values = append(values, record.Value)
+		}
+	}
+	return values, nil
+}
+
+// FindByValue returns the DNS records on the account that point at value, and any errors.
+// If value is an IP address, that is every record that references it, as by DnsRecords.PointingTo;
+// otherwise it is the records whose value is value, ignoring case and any trailing dot, eg the CNAMEs aliasing a host.
+// The listing is reused for as long as WithRecordCache allows.
+func (c *Client) FindByValue(ctx context.Context, value string) (DnsRecords, error) {
+	records, err := c.listRecords(ctx)
+	if err != nil {
+		return DnsRecords{}, err
+	}
+	if _, err := netip.ParseAddr(strings.TrimSpace(value)); err == nil {
+		return records.PointingTo(value), nil
+	}
+	target := strings.TrimSuffix(value, ".")
+	return records.Filter(func(record DnsRecord) bool {
+		return strings.EqualFold(strings.TrimSuffix(record.Value, "."), target)
+	}), nil
+}
+
+// zoneOptions holds the options of GetZones.
+type zoneOptions struct {
+	crossCheck bool
+}
+
+// A ZoneOption changes what GetZones returns.
+type ZoneOption func(*zoneOptions)
+
+// CrossCheckDomains makes GetZones only return the zones that domain-list_domains also lists as hosted on the account,
+// leaving out zones that only linger in the records.
+func CrossCheckDomains() ZoneOption {
+	return func(o *zoneOptions) {
+		o.crossCheck = true
+	}
+}
+
+// GetZones returns the distinct zones of the account's DNS records, lowercase and sorted, and any errors.
+// The listing is reused for as long as WithRecordCache allows.
+func (c *Client) GetZones(ctx context.Context, opts ...ZoneOption) ([]string, error) {
+	var o zoneOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	records, err := c.listRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var hosted []string
+	if o.crossCheck {
+		if hosted, err = c.hostedDomains(ctx); err != nil {
+			return nil, err
+		}
+	}
+	var zones []string
+	for zone := range records.GroupByZone() {
+		if !o.crossCheck || slices.Contains(hosted, zone) {
+			zones = append(zones, zone)
+		}
+	}
+	slices.Sort(zones)
+	return zones, nil
+}
@@ -0,0 +1,230 @@
+package dreamhostapi
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// An SPF describes a Sender Policy Framework policy: which servers may send mail for a domain.
+type SPF struct {
+	A       bool     // allow the domain's A and AAAA addresses
+	MX      bool     // allow the domain's mail servers
+	IP4     []string // allowed IPv4 addresses or networks, eg 192.0.2.0/24
+	IP6     []string // allowed IPv6 addresses or networks
+	Include []string // domains whose SPF policy is also allowed, eg _spf.google.com
+	All     string   // what to do with everything else: "-all", "~all", "?all", or "+all"; empty means "~all"
+}
+
+// String returns the policy as an SPF record value, eg "v=spf1 mx include:_spf.google.com ~all".
+func (s SPF) String() string {
+	terms := []string{"v=spf1"}
+	if s.A {
+		terms = append(terms, "a")
+	}
+	if s.MX {
+		terms = append(terms, "mx")
+	}
+	for _, ip := range s.IP4 {
+		terms = append(terms, "ip4:"+ip)
+	}
+	for _, ip := range s.IP6 {
+		terms = append(terms, "ip6:"+ip)
+	}
+	for _, domain := range s.Include {
+		terms = append(terms, "include:"+domain)
+	}
+	all := s.All
+	if all == "" {
+		all = "~all"
+	}
+	return strings.Join(append(terms, all), " ")
+}
+
+// NewSPF returns the TXT record that publishes spf for domain, or an error if spf has an invalid address, domain, or qualifier.
+func NewSPF(domain string, spf SPF) (DnsRecord, error) {
+	for _, ip := range spf.IP4 {
+		if !validNetwork(ip, true) {
+			return DnsRecord{}, fmt.Errorf("SPF ip4 %q is not an IPv4 address or network", ip)
+		}
+	}
+	for _, ip := range spf.IP6 {
+		if !validNetwork(ip, false) {
+			return DnsRecord{}, fmt.Errorf("SPF ip6 %q is not an IPv6 address or network", ip)
+		}
+	}
+	for _, include := range spf.Include {
+		if !validHostname(include) {
+			return DnsRecord{}, fmt.Errorf("SPF include %q is not a valid domain", include)
+		}
+	}
+	switch spf.All {
+	case "", "-all", "~all", "?all", "+all":
+	default:
+		return DnsRecord{}, fmt.Errorf("SPF all %q is not one of -all, ~all, ?all, or +all", spf.All)
+	}
+	return DnsRecord{Record: domain, ZoneType: TXT, Value: spf.String()}, nil
+}
+
+// validNetwork reports whether s is an address or CIDR network of the right family.
+func validNetwork(s string, ipv4 bool) bool {
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		address, err := netip.ParseAddr(s)
+		if err != nil {
+			return false
+		}
+		prefix = netip.PrefixFrom(address, address.BitLen())
+	}
+	return prefix.Addr().Is4() == ipv4
+}
+
+// A DKIM describes a DomainKeys Identified Mail public key.
+type DKIM struct {
+	Selector  string // the selector the signing server uses, eg google or s1
+	KeyType   string // "rsa" or "ed25519"; empty means "rsa"
+	PublicKey string // the base64 public key, without PEM armour
+}
+
+// NewDKIM returns the TXT record that publishes dkim for domain, at selector._domainkey.domain.
+// Keys too long for one TXT string, such as 2048-bit RSA keys, are split with ChunkTXT.
+func NewDKIM(domain string, dkim DKIM) (DnsRecord, error) {
+	if !validHostname(dkim.Selector) {
+		return DnsRecord{}, fmt.Errorf("DKIM selector %q is not a valid DNS label", dkim.Selector)
+	}
+	keyType := dkim.KeyType
+	if keyType == "" {
+		keyType = "rsa"
+	}
+	if keyType != "rsa" && keyType != "ed25519" {
+		return DnsRecord{}, fmt.Errorf("DKIM key type %q is not rsa or ed25519", dkim.KeyType)
+	}
+	key := strings.Join(strings.Fields(dkim.PublicKey), "")
+	if _, err := base64.StdEncoding.DecodeString(key); err != nil || key == "" {
+		return DnsRecord{}, errors.New("DKIM public key is not base64")
+	}
+	value := fmt.Sprintf("v=DKIM1; k=%s; p=%s", keyType, key)
+	if len(value) > MaxTXTChunk {
+		value = ChunkTXT(value)
+	}
+	return DnsRecord{Record: dkim.Selector + "._domainkey." + domain, ZoneType: TXT, Value: value}, nil
+}
+
+// A DMARC describes a Domain-based Message Authentication, Reporting and Conformance policy.
+type DMARC struct {
+	Policy          string   // "none", "quarantine", or "reject"
+	SubdomainPolicy string   // the policy for subdomains, empty to use Policy
+	Percent         int      // the percentage of mail the policy applies to, 0 means all of it
+	RUA             []string // addresses for aggregate reports; mailto: is added if missing
+	RUF             []string // addresses for failure reports; mailto: is added if missing
+	StrictDKIM      bool     // require DKIM domains to match exactly instead of sharing an organisational domain
+	StrictSPF       bool     // require SPF domains to match exactly instead of sharing an organisational domain
+}
+
+// String returns the policy as a DMARC record value, eg "v=DMARC1; p=reject; rua=mailto:dmarc@example.com".
+func (d DMARC) String() string {
+	tags := []string{"v=DMARC1", "p=" + d.Policy}
+	if d.SubdomainPolicy != "" {
+		tags = append(tags, "sp="+d.SubdomainPolicy)
+	}
+	if d.Percent > 0 && d.Percent < 100 {
+		tags = append(tags, fmt.Sprintf("pct=%d", d.Percent))
+	}
+	if len(d.RUA) > 0 {
+		tags = append(tags, "rua="+mailtoList(d.RUA))
+	}
+	if len(d.RUF) > 0 {
+		tags = append(tags, "ruf="+mailtoList(d.RUF))
+	}
+	if d.StrictDKIM {
+		tags = append(tags, "adkim=s")
+	}
+	if d.StrictSPF {
+		tags = append(tags, "aspf=s")
+	}
+	return strings.Join(tags, "; ")
+}
+
+// mailtoList joins addresses into a DMARC URI list, adding mailto: where it is missing.
+func mailtoList(addresses []string) string {
+	uris := make([]string, len(addresses))
+	for i, address := range addresses {
+		if !strings.HasPrefix(address, "mailto:") {
+			address = "mailto:" + address
+		}
+		uris[i] = address
+	}
+	return strings.Join(uris, ",")
+}
+
+// NewDMARC returns the TXT record that publishes dmarc for domain, at _dmarc.domain.
+func NewDMARC(domain string, dmarc DMARC) (DnsRecord, error) {
+	validPolicy := func(policy string) bool {
+		return policy == "none" || policy == "quarantine" || policy == "reject"
+	}
+	if !validPolicy(dmarc.Policy) {
+		return DnsRecord{}, fmt.Errorf("DMARC policy %q is not none, quarantine, or reject", dmarc.Policy)
+	}
+	if dmarc.SubdomainPolicy != "" && !validPolicy(dmarc.SubdomainPolicy) {
+		return DnsRecord{}, fmt.Errorf("DMARC subdomain policy %q is not none, quarantine, or reject", dmarc.SubdomainPolicy)
+	}
+	if dmarc.Percent < 0 || dmarc.Percent > 100 {
+		return DnsRecord{}, fmt.Errorf("DMARC percent %d is out of range 0-100", dmarc.Percent)
+	}
+	return DnsRecord{Record: "_dmarc." + domain, ZoneType: TXT, Value: dmarc.String()}, nil
+}
+
+// AddMailAuthRecords adds records, as made by NewSPF, NewDKIM, and NewDMARC, skipping any that already exist or are repeated.
+// Since a name may only have one SPF or one DMARC policy, it refuses to add one next to a different existing policy,
+// or two different ones from records, rather than leave mail servers with two; remove the old one first.
+// The records are commented "mail authentication" unless they have a Comment of their own; opts apply to every add,
+// except that an id set with WithUniqueID is suffixed with each record's position in records.
+func (c *Client) AddMailAuthRecords(ctx context.Context, records []DnsRecord, opts ...RecordOption) error {
+	existing, err := c.GetDNSRecords(ctx)
+	if err != nil {
+		return err
+	}
+	for i, record := range records {
+		for _, prefix := range []string{"v=spf1", "v=DMARC1"} {
+			if !strings.HasPrefix(JoinTXT(record.Value), prefix) {
+				continue
+			}
+			if other, ok := policyConflict(existing.Data, record, prefix); ok {
+				return fmt.Errorf("%s already has a different %s policy: %s", record.Record, prefix, other.Value)
+			}
+			if other, ok := policyConflict(records[:i], record, prefix); ok {
+				return fmt.Errorf("records hold two different %s policies for %s: %s and %s", prefix, record.Record, other.Value, record.Value)
+			}
+		}
+	}
+	for i, record := range records {
+		earlier := DnsRecords{Data: records[:i]}
+		if existing.contains(record.Record, record.ZoneType, record.Value) || earlier.contains(record.Record, record.ZoneType, record.Value) {
+			continue
+		}
+		comment := record.Comment
+		if comment == "" {
+			comment = "mail authentication"
+		}
+		recordOpts := append([]RecordOption{WithComment(comment)}, withSubID(opts, strconv.Itoa(i))...)
+		if _, err := c.AddRecord(ctx, record.Record, record.Value, append(recordOpts, WithType(record.ZoneType))...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// policyConflict returns the first of records that is a TXT record at the name of record holding a policy starting with prefix
+// different from record's, and whether there is one.
+func policyConflict(records []DnsRecord, record DnsRecord, prefix string) (DnsRecord, bool) {
+	for _, other := range records {
+		if other.Matches(record.Record, TXT) && strings.HasPrefix(JoinTXT(other.Value), prefix) && JoinTXT(other.Value) != JoinTXT(record.Value) {
+			return other, true
+		}
+	}
+	return DnsRecord{}, false
+}
@@ -0,0 +1,111 @@
+package dreamhostapi
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Metadata holds key=value pairs stored in the comment field of a record, eg "env=prod owner=alice".
+type Metadata map[string]string
+
+// ParseMetadata returns the key=value pairs found in comment.
+// Values may be double quoted to hold spaces. Words without an equals sign are ignored.
+func ParseMetadata(comment string) Metadata {
+	metadata := Metadata{}
+	for _, field := range splitComment(comment) {
+		key, value, found := strings.Cut(field, "=")
+		if !found || key == "" {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		}
+		metadata[key] = value
+	}
+	return metadata
+}
+
+// String returns the metadata as space separated key=value pairs, sorted by key.
+// Values that contain spaces, quotes, or equals signs are quoted.
+func (m Metadata) String() string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fields := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value := m[key]
+		if value == "" || strings.ContainsAny(value, " \t\"=") {
+			value = strconv.Quote(value)
+		}
+		fields = append(fields, key+"="+value)
+	}
+	return strings.Join(fields, " ")
+}
+
+// MergeMetadata returns comment with the pairs in m added, replacing any existing values for the same keys.
+// Any free text in the comment is kept in front of the metadata.
+func MergeMetadata(comment string, m Metadata) string {
+	merged := ParseMetadata(comment)
+	var text []string
+	for _, field := range splitComment(comment) {
+		if key, _, found := strings.Cut(field, "="); !found || key == "" {
+			text = append(text, field)
+		}
+	}
+	for key, value := range m {
+		merged[key] = value
+	}
+	if len(text) == 0 {
+		return merged.String()
+	}
+	return strings.Join(text, " ") + " " + merged.String()
+}
+
+// Metadata returns the key=value pairs stored in the record's comment.
+func (r DnsRecord) Metadata() Metadata {
+	return ParseMetadata(r.Comment)
+}
+
+// ByMetadata returns the records whose comment has key set to value.
+// An empty value matches every record that has the key at all.
+func (records DnsRecords) ByMetadata(key string, value string) DnsRecords {
+	matches := DnsRecords{Result: records.Result}
+	for _, record := range records.Data {
+		got, ok := record.Metadata()[key]
+		if ok && (value == "" || got == value) {
+			matches.Data = append(matches.Data, record)
+		}
+	}
+	return matches
+}
+
+// splitComment splits a comment on whitespace, keeping double quoted sections together.
+func splitComment(comment string) []string {
+	var fields []string
+	var current strings.Builder
+	inQuotes, escaped := false, false
+	for _, r := range comment {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && inQuotes:
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && (r == ' ' || r == '\t' || r == '\n'):
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
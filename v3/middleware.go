@@ -0,0 +1,61 @@
+package dreamhostapi
+
+import "net/http"
+
+// A Doer sends an HTTP request and returns its response. *http.Client is a Doer.
+type Doer interface {
+	Do(request *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts an ordinary function to a Doer.
+type DoerFunc func(request *http.Request) (*http.Response, error)
+
+// Do calls f(request).
+func (f DoerFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// A Middleware wraps the Doer that sends the Client's requests, eg to log them, count them, or change them before they go out.
+type Middleware func(next Doer) Doer
+
+// Use adds middleware to the chain every request of the Client passes through on its way to the HTTP client.
+// Middleware added first sees the request first. Requests already under way are not affected.
+func (c *Client) Use(middleware ...Middleware) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// doer returns the Client's HTTP client wrapped in its middleware.
+func (c *Client) doer() Doer {
+	c.hooksMu.RLock()
+	defer c.hooksMu.RUnlock()
+	var doer Doer = c.httpClient
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		doer = c.middleware[i](doer)
+	}
+	return doer
+}
+
+// A ResponseHook is called with every raw response the Client receives for a command: the command name, the HTTP status code,
+// the body, and any error sending the request. A rate-limited command that is retried calls it once per attempt.
+// Responses that are streamed, as by StreamDNSRecords, are passed with a nil body, since it is never held in memory.
+type ResponseHook func(cmd string, status int, body []byte, err error)
+
+// OnResponse adds hook to the functions called with every response, eg to archive the raw API responses for auditing.
+// Hooks are called in the order they were added, on the goroutine that sent the command.
+func (c *Client) OnResponse(hook ResponseHook) {
+	c.hooksMu.Lock()
+	defer c.hooksMu.Unlock()
+	c.responseHooks = append(c.responseHooks, hook)
+}
+
+// responded calls the Client's response hooks.
+func (c *Client) responded(cmd string, status int, body []byte, err error) {
+	c.hooksMu.RLock()
+	hooks := c.responseHooks
+	c.hooksMu.RUnlock()
+	for _, hook := range hooks {
+		hook(cmd, status, body, err)
+	}
+}
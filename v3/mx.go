@@ -0,0 +1,71 @@
+package dreamhostapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// An MXRecord is the value of an MX record: a mail server and its priority.
+// Dreamhost manages MX records through its panel and the API only lists them, so there are no helpers to add or replace them.
+type MXRecord struct {
+	Priority int    // lower values are tried first
+	Target   string // the hostname of the mail server
+}
+
+// ParseMX parses an MX record value such as "10 mail1.example.com".
+func ParseMX(value string) (MXRecord, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return MXRecord{}, fmt.Errorf("MX value %q is not in the form \"priority target\"", value)
+	}
+	priority, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return MXRecord{}, fmt.Errorf("MX value %q has an invalid priority: %w", value, err)
+	}
+	mx := MXRecord{Priority: priority, Target: fields[1]}
+	return mx, mx.Validate()
+}
+
+// String returns the MX record value in the form Dreamhost expects, eg "10 mail1.example.com".
+func (mx MXRecord) String() string {
+	return fmt.Sprintf("%d %s", mx.Priority, mx.Target)
+}
+
+// Validate checks that the priority is in range and the target is a valid hostname.
+func (mx MXRecord) Validate() error {
+	if mx.Priority < 0 || mx.Priority > 65535 {
+		return fmt.Errorf("MX priority %d is out of range 0-65535", mx.Priority)
+	}
+	if !validHostname(mx.Target) {
+		return fmt.Errorf("MX target %q is not a valid hostname", mx.Target)
+	}
+	return nil
+}
+
+// MX returns the MX records for domain, sorted by priority.
+// Values that don't parse as MX records are skipped.
+func (records DnsRecords) MX(domain string) []MXRecord {
+	var mxs []MXRecord
+	for _, record := range records.Data {
+		if record.ZoneType != MX || !sameName(record.Record, domain) {
+			continue
+		}
+		if mx, err := ParseMX(record.Value); err == nil {
+			mxs = append(mxs, mx)
+		}
+	}
+	sort.SliceStable(mxs, func(i, j int) bool { return mxs[i].Priority < mxs[j].Priority })
+	return mxs
+}
+
+// ListMX returns the MX records for domain, sorted by priority, and any errors.
+func (c *Client) ListMX(ctx context.Context, domain string) ([]MXRecord, error) {
+	records, err := c.GetDNSRecords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return records.MX(domain), nil
+}
@@ -0,0 +1,125 @@
+package dreamhostapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A NAPTRRecord is the value of a NAPTR record, a rewrite rule used by ENUM and SIP to map names to services.
+type NAPTRRecord struct {
+	Order       int    // rules are applied in increasing order
+	Preference  int    // breaks ties between rules of the same order
+	Flags       string // eg "U" for a terminal rule producing a URI, or "S" for one leading to SRV records
+	Service     string // eg "E2U+sip"
+	Regexp      string // the substitution expression, eg "!^.*$!sip:info@example.com!"; empty if Replacement is used
+	Replacement string // the next name to look up, or "." if Regexp is used
+}
+
+// ParseNAPTR parses a NAPTR record value such as `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`.
+func ParseNAPTR(value string) (NAPTRRecord, error) {
+	fields, err := splitNAPTR(value)
+	if err != nil {
+		return NAPTRRecord{}, err
+	}
+	if len(fields) != 6 {
+		return NAPTRRecord{}, fmt.Errorf("NAPTR value %q is not in the form order preference \"flags\" \"service\" \"regexp\" replacement", value)
+	}
+	order, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return NAPTRRecord{}, fmt.Errorf("NAPTR value %q has an invalid order: %w", value, err)
+	}
+	preference, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return NAPTRRecord{}, fmt.Errorf("NAPTR value %q has an invalid preference: %w", value, err)
+	}
+	naptr := NAPTRRecord{Order: order, Preference: preference, Flags: fields[2], Service: fields[3], Regexp: fields[4], Replacement: fields[5]}
+	return naptr, naptr.Validate()
+}
+
+// splitNAPTR splits a NAPTR value into its fields, unquoting the quoted ones.
+func splitNAPTR(value string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inField, quoted, escaped := false, false, false
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case escaped:
+			field.WriteByte(ch)
+			escaped = false
+		case quoted && ch == '\\':
+			escaped = true
+		case ch == '"':
+			if quoted {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+			quoted = !quoted
+		case quoted:
+			field.WriteByte(ch)
+		case ch == ' ' || ch == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteByte(ch)
+			inField = true
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("NAPTR value %q has an unterminated quoted string", value)
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// String returns the NAPTR record value in the form Dreamhost expects, with the flags, service, and regexp quoted.
+func (naptr NAPTRRecord) String() string {
+	replacement := naptr.Replacement
+	if replacement == "" {
+		replacement = "."
+	}
+	return fmt.Sprintf("%d %d %s %s %s %s", naptr.Order, naptr.Preference, quoteTXT(naptr.Flags), quoteTXT(naptr.Service), quoteTXT(naptr.Regexp), replacement)
+}
+
+// Validate checks that the numbers are in range, the flags are alphanumeric,
+// and exactly one of Regexp and Replacement is used.
+func (naptr NAPTRRecord) Validate() error {
+	if naptr.Order < 0 || naptr.Order > 65535 {
+		return fmt.Errorf("NAPTR order %d is out of range 0-65535", naptr.Order)
+	}
+	if naptr.Preference < 0 || naptr.Preference > 65535 {
+		return fmt.Errorf("NAPTR preference %d is out of range 0-65535", naptr.Preference)
+	}
+	for _, r := range naptr.Flags {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return fmt.Errorf("NAPTR flags %q are not alphanumeric", naptr.Flags)
+		}
+	}
+	hasReplacement := naptr.Replacement != "" && naptr.Replacement != "."
+	if naptr.Regexp != "" && hasReplacement {
+		return errors.New("NAPTR record has both a regexp and a replacement; use one")
+	}
+	if naptr.Regexp == "" && !hasReplacement {
+		return errors.New("NAPTR record needs a regexp or a replacement")
+	}
+	if hasReplacement && !validHostname(naptr.Replacement) {
+		return fmt.Errorf("NAPTR replacement %q is not a valid hostname", naptr.Replacement)
+	}
+	return nil
+}
+
+// NewNAPTR returns the NAPTR record for name with the value naptr, or an error if naptr is not valid.
+func NewNAPTR(name string, naptr NAPTRRecord) (DnsRecord, error) {
+	if err := naptr.Validate(); err != nil {
+		return DnsRecord{}, err
+	}
+	return DnsRecord{Record: name, ZoneType: NAPTR, Value: naptr.String()}, nil
+}
@@ -0,0 +1,107 @@
+package dreamhostapi
+
+import "slices"
+
+// recordOptions holds the optional attributes of an add or remove command.
+type recordOptions struct {
+	recordType RecordType // defaults to A
+	comment    string     // left out of the command when empty
+	account    string     // left out of the command when empty
+	uniqueID   string     // generated when empty
+}
+
+// A RecordOption sets an optional attribute of a single add or remove command, eg its comment or record type.
+// New attributes get new options, so adding them doesn't change any method's signature.
+type RecordOption func(*recordOptions)
+
+// WithComment sets the comment stored with the record.
+func WithComment(comment string) RecordOption {
+	return func(o *recordOptions) {
+		o.comment = comment
+	}
+}
+
+// WithType sets the record type, eg AAAA or TXT, instead of the default A.
+func WithType(recordType RecordType) RecordOption {
+	return func(o *recordOptions) {
+		o.recordType = recordType
+	}
+}
+
+// WithAccount runs the command against account, for API keys that can manage more than one account.
+func WithAccount(account string) RecordOption {
+	return func(o *recordOptions) {
+		o.account = account
+	}
+}
+
+// WithUniqueID sets the unique_id the API uses to recognise a command it has already run.
+// Reuse the same id when retrying a change whose outcome is unknown, eg after a timeout, so it can't be applied twice.
+// Without it, every call gets a new random id.
+// Helpers that send more than one command, such as UpdateDNSRecord, derive a separate id for each from it, eg id-add and id-remove.
+func WithUniqueID(uniqueID string) RecordOption {
+	return func(o *recordOptions) {
+		o.uniqueID = uniqueID
+	}
+}
+
+// withSubID returns opts with any id set by WithUniqueID suffixed with "-" and suffix,
+// so each command a helper sends for one call has its own id, and the same one when the call is retried with the same opts.
+func withSubID(opts []RecordOption, suffix string) []RecordOption {
+	return append(slices.Clip(opts), func(o *recordOptions) {
+		if o.uniqueID != "" {
+			o.uniqueID += "-" + suffix
+		}
+	})
+}
+
+// newRecordOptions applies opts to the defaults.
+func newRecordOptions(opts []RecordOption) recordOptions {
+	o := recordOptions{recordType: A}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// listOptions holds the options of GetDNSRecords.
+type listOptions struct {
+	sorted  bool
+	unicode bool
+	extra   bool
+}
+
+// A ListOption changes what GetDNSRecords returns.
+type ListOption func(*listOptions)
+
+// SortedRecords makes GetDNSRecords return the records in the order of DnsRecords.Sort rather than the API's arbitrary order.
+func SortedRecords() ListOption {
+	return func(o *listOptions) {
+		o.sorted = true
+	}
+}
+
+// newListOptions returns the listOptions that opts set.
+func newListOptions(opts []ListOption) listOptions {
+	var o listOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// applyListOptions returns records as opts ask for them.
+func applyListOptions(records DnsRecords, opts []ListOption) DnsRecords {
+	o := newListOptions(opts)
+	if o.unicode {
+		for i := range records.Data {
+			records.Data[i].Record = ToUnicode(records.Data[i].Record)
+			records.Data[i].Zone = ToUnicode(records.Data[i].Zone)
+			records.Data[i].Value = ValueToUnicode(records.Data[i].ZoneType, records.Data[i].Value)
+		}
+	}
+	if o.sorted {
+		records.Sort()
+	}
+	return records
+}
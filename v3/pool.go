@@ -0,0 +1,103 @@
+package dreamhostapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultConcurrency is how many commands new Clients run at once in bulk operations.
+const DefaultConcurrency = 4
+
+// A Task is one unit of work for a Pool, usually a single API command.
+type Task struct {
+	Name string                          // identifies the task in the Report, eg the record it changes
+	Run  func(ctx context.Context) error // does the work
+}
+
+// A TaskResult is the outcome of one Task.
+type TaskResult struct {
+	Name string
+	Err  error // nil if the task succeeded
+}
+
+// A Report collects the outcome of every Task a Pool ran, in the order the tasks were given.
+type Report struct {
+	Results []TaskResult
+}
+
+// Succeeded returns the number of tasks that succeeded.
+func (r Report) Succeeded() int {
+	count := 0
+	for _, result := range r.Results {
+		if result.Err == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed returns the results of the tasks that failed.
+func (r Report) Failed() []TaskResult {
+	var failed []TaskResult
+	for _, result := range r.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Err returns the errors of all the failed tasks joined together, each prefixed with its task's name, or nil if every task succeeded.
+func (r Report) Err() error {
+	var errs []error
+	for _, result := range r.Failed() {
+		errs = append(errs, fmt.Errorf("%s: %w", result.Name, result.Err))
+	}
+	return errors.Join(errs...)
+}
+
+// A Pool runs tasks in parallel, but no more than Concurrency at once.
+type Pool struct {
+	Concurrency int // values below 1 are treated as 1
+}
+
+// NewPool returns a Pool that runs up to concurrency tasks at once.
+func NewPool(concurrency int) *Pool {
+	return &Pool{Concurrency: concurrency}
+}
+
+// Run runs every task and returns a Report once they have all finished.
+// A failing task doesn't stop the others. Once ctx is done, tasks that haven't started are not run and fail with ctx.Err().
+func (p *Pool) Run(ctx context.Context, tasks []Task) Report {
+	report := Report{Results: make([]TaskResult, len(tasks))}
+	workers := max(p.Concurrency, 1)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				report.Results[i] = TaskResult{Name: tasks[i].Name}
+				if err := ctx.Err(); err != nil {
+					report.Results[i].Err = err
+					continue
+				}
+				report.Results[i].Err = tasks[i].Run(ctx)
+			}
+		}()
+	}
+	for i := range tasks {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return report
+}
+
+// pool returns a Pool sized by the Client's concurrency.
+func (c *Client) pool() *Pool {
+	return NewPool(c.concurrency)
+}
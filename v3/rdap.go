@@ -0,0 +1,134 @@
+package dreamhostapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RDAPBaseURL is the RDAP service used by LookupRDAP. The domain name is appended to it.
+// The default bootstrap service redirects to the authoritative registry for each TLD.
+var RDAPBaseURL = "https://rdap.org/domain/"
+
+// An RDAPRecord holds the registration data an RDAP server reports for a domain.
+type RDAPRecord struct {
+	Domain    string
+	Expires   time.Time // zero if the server did not report an expiration event
+	Registrar string    // empty if the server did not report a registrar
+}
+
+// A Discrepancy is a registration field on which Dreamhost and RDAP disagree.
+type Discrepancy struct {
+	Domain    string
+	Field     string // "expires" or "registrar"
+	Dreamhost string // the value according to the Dreamhost API
+	RDAP      string // the value according to RDAP
+}
+
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("%s: %s is %s according to Dreamhost but %s according to RDAP", d.Domain, d.Field, d.Dreamhost, d.RDAP)
+}
+
+// rdapResponse is the part of an RFC 9083 domain object that LookupRDAP reads.
+type rdapResponse struct {
+	LDHName string `json:"ldhName"`
+	Events  []struct {
+		EventAction string `json:"eventAction"`
+		EventDate   string `json:"eventDate"`
+	} `json:"events"`
+	Entities []struct {
+		Roles      []string `json:"roles"`
+		VCardArray []any    `json:"vcardArray"`
+	} `json:"entities"`
+}
+
+// LookupRDAP returns the expiry date and registrar an RDAP server reports for domain and any errors.
+// The query is sent with the Client's HTTP client.
+func (c *Client) LookupRDAP(ctx context.Context, domain string) (RDAPRecord, error) {
+	var record RDAPRecord
+	body, statusCode, err := c.get(ctx, RDAPBaseURL+url.PathEscape(domain))
+	if err != nil {
+		return record, err
+	}
+	if statusCode != 200 {
+		return record, fmt.Errorf("RDAP lookup for %s failed with status code: %d", domain, statusCode)
+	}
+	var response rdapResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return record, err
+	}
+	record.Domain = strings.ToLower(response.LDHName)
+	for _, event := range response.Events {
+		if event.EventAction == "expiration" {
+			record.Expires, _ = time.Parse(time.RFC3339, event.EventDate)
+		}
+	}
+	for _, entity := range response.Entities {
+		for _, role := range entity.Roles {
+			if role == "registrar" {
+				record.Registrar = vcardName(entity.VCardArray)
+			}
+		}
+	}
+	return record, nil
+}
+
+// LookupRDAP is a shortcut for NewClient("").LookupRDAP(context.Background(), domain); no API key is needed.
+func LookupRDAP(domain string) (RDAPRecord, error) {
+	return NewClient("").LookupRDAP(context.Background(), domain)
+}
+
+// vcardName returns the fn property of a jCard, eg ["vcard", [["fn", {}, "text", "DreamHost, LLC"]]].
+func vcardName(vcard []any) string {
+	if len(vcard) < 2 {
+		return ""
+	}
+	properties, _ := vcard[1].([]any)
+	for _, property := range properties {
+		fields, _ := property.([]any)
+		if len(fields) == 4 && fields[0] == "fn" {
+			name, _ := fields[3].(string)
+			return name
+		}
+	}
+	return ""
+}
+
+// CrossCheck looks the registration up over RDAP and returns the fields on which the two sources disagree.
+// The expiry dates are compared by day. Domains registered through Dreamhost are expected to list DreamHost as their registrar.
+func (r Registration) CrossCheck() ([]Discrepancy, error) {
+	rdap, err := LookupRDAP(r.Domain)
+	if err != nil {
+		return nil, err
+	}
+	var discrepancies []Discrepancy
+	if expires, err := r.ExpiryDate(); err == nil && !rdap.Expires.IsZero() {
+		if expires.Format(time.DateOnly) != rdap.Expires.UTC().Format(time.DateOnly) {
+			discrepancies = append(discrepancies, Discrepancy{Domain: r.Domain, Field: "expires", Dreamhost: r.Expires, RDAP: rdap.Expires.UTC().Format(time.DateOnly)})
+		}
+	}
+	if rdap.Registrar != "" && !strings.Contains(strings.ToLower(rdap.Registrar), "dreamhost") {
+		discrepancies = append(discrepancies, Discrepancy{Domain: r.Domain, Field: "registrar", Dreamhost: "DreamHost", RDAP: rdap.Registrar})
+	}
+	return discrepancies, nil
+}
+
+// CrossCheck runs Registration.CrossCheck on every registration and returns all of the discrepancies found.
+// Lookups that fail are skipped and their errors returned joined together.
+func (registrations Registrations) CrossCheck() ([]Discrepancy, error) {
+	var discrepancies []Discrepancy
+	var errs []error
+	for _, registration := range registrations.Data {
+		found, err := registration.CrossCheck()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", registration.Domain, err))
+			continue
+		}
+		discrepancies = append(discrepancies, found...)
+	}
+	return discrepancies, errors.Join(errs...)
+}
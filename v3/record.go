@@ -0,0 +1,176 @@
+package dreamhostapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// NormalizeName returns name in the form Dreamhost stores record names in: lowercase, without a trailing dot or surrounding whitespace,
+// and with internationalized labels in punycode, so "Example.COM. " and "example.com" name the same record.
+// Whitespace inside the name is kept, so a typo such as "www example.com" is rejected as an invalid hostname rather than sent as another name.
+// A name that can't be converted to punycode is returned with only the other changes; see ToASCII.
+// AddRecord, RemoveRecord, and the commands built by AddRecordCommand and RemoveRecordCommand normalize the record name with it.
+func NormalizeName(name string) string {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), ".")
+	ascii, _ := ToASCII(name)
+	return ascii
+}
+
+// sameName reports whether a and b name the same record once both are normalized with NormalizeName,
+// so "Example.COM." matches the stored "example.com". Every comparison of record names goes through it.
+func sameName(a, b string) bool {
+	return NormalizeName(a) == NormalizeName(b)
+}
+
+// normalizeValue returns value, of a record of type recordType, with the host name it holds normalized with NormalizeName,
+// so "Mail.Example.COM." matches the stored "mail.example.com". Values of types that hold no host name are only trimmed of surrounding whitespace.
+func normalizeValue(recordType RecordType, value string) string {
+	normalized, _ := convertValue(recordType, strings.TrimSpace(value), func(name string) (string, error) {
+		return NormalizeName(name), nil
+	})
+	return normalized
+}
+
+// sameValue reports whether a and b are the same value for a record of type recordType once both are normalized with normalizeValue.
+func sameValue(recordType RecordType, a, b string) bool {
+	return normalizeValue(recordType, a) == normalizeValue(recordType, b)
+}
+
+// IsEditable reports whether the record can be changed through the API.
+func (r DnsRecord) IsEditable() bool {
+	return r.Editable
+}
+
+// IsApex reports whether the record is at the apex of its zone, eg example.com in the zone example.com.
+func (r DnsRecord) IsApex() bool {
+	return sameName(r.Record, r.Zone)
+}
+
+// FQDN returns the record's name fully qualified: lowercase, with a trailing dot.
+func (r DnsRecord) FQDN() string {
+	return strings.ToLower(strings.TrimSuffix(r.Record, ".")) + "."
+}
+
+// Matches reports whether the record is named name, as compared by NormalizeName, and is of type recordType.
+// An empty recordType matches every type.
+func (r DnsRecord) Matches(name string, recordType RecordType) bool {
+	if recordType != "" && r.ZoneType != recordType {
+		return false
+	}
+	return sameName(r.Record, name)
+}
+
+// wireRecord is a DnsRecord as the Dreamhost API sends it.
+type wireRecord struct {
+	Record    string `json:"record"`
+	Zone      string `json:"zone"`
+	Value     string `json:"value"`
+	Editable  any    `json:"editable"`
+	Type      string `json:"type"`
+	Comment   string `json:"comment"`
+	AccountId string `json:"account_id"`
+
+	Extra map[string]string `json:"extra"` // as MarshalJSON writes it
+}
+
+// wireFields are the JSON names of the fields wireRecord decodes.
+var wireFields = map[string]bool{"record": true, "zone": true, "value": true, "editable": true, "type": true, "comment": true, "account_id": true, "extra": true}
+
+// jsonRecord is a DnsRecord as MarshalJSON writes it.
+type jsonRecord struct {
+	Record    string            `json:"record"`
+	Zone      string            `json:"zone"`
+	Type      RecordType        `json:"type"`
+	Value     string            `json:"value"`
+	Editable  bool              `json:"editable"`
+	Comment   string            `json:"comment,omitempty"`
+	AccountId string            `json:"account_id,omitempty"`
+	Extra     map[string]string `json:"extra,omitempty"`
+}
+
+// UnmarshalJSON decodes a record in the form the API sends it, where editable is the string "0" or "1",
+// or in the form MarshalJSON writes it, whose "extra" object is read back into Extra.
+// A JSON boolean or number is accepted for editable too.
+// Other fields DnsRecord has no field for are dropped, so each record is decoded in a single pass; list with ExtraFields to keep them.
+func (r *DnsRecord) UnmarshalJSON(data []byte) error {
+	var wire wireRecord
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	editable, err := parseEditable(wire.Editable)
+	if err != nil {
+		return err
+	}
+	*r = DnsRecord{Record: wire.Record, Zone: wire.Zone, Value: wire.Value, Editable: editable, ZoneType: RecordType(wire.Type), Comment: wire.Comment, AccountId: wire.AccountId, Extra: wire.Extra}
+	return nil
+}
+
+// ExtraFields makes GetDNSRecords keep the fields the API sends that DnsRecord has no field for in each record's Extra,
+// strings as they are and other values as JSON.
+// It costs a second decode of the listing, so it is off unless asked for.
+func ExtraFields() ListOption {
+	return func(o *listOptions) {
+		o.extra = true
+	}
+}
+
+// decodeWithExtra decodes data, the data field of a dns-list_records response, into records with their Extra fields filled in.
+// Both decodes go through the Client's Decoder, and the fields of every record are collected by the second in one go.
+func (c *Client) decodeWithExtra(data json.RawMessage) ([]DnsRecord, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var records []DnsRecord
+	if err := c.decoder.Decode(data, &records); err != nil {
+		return nil, err
+	}
+	var fields []map[string]any
+	if err := c.decoder.Decode(data, &fields); err != nil {
+		return nil, err
+	}
+	for i := range min(len(records), len(fields)) {
+		for name, value := range fields[i] {
+			if wireFields[strings.ToLower(name)] {
+				continue
+			}
+			if records[i].Extra == nil {
+				records[i].Extra = make(map[string]string)
+			}
+			if s, ok := value.(string); ok {
+				records[i].Extra[name] = s
+			} else if encoded, err := json.Marshal(value); err == nil {
+				records[i].Extra[name] = string(encoded)
+			}
+		}
+	}
+	return records, nil
+}
+
+// MarshalJSON encodes the record with lowercase keys in a fixed order and editable as a boolean,
+// so json.Marshal of records gives output that is fit to store and diff.
+// Empty comments and accounts are left out, and any Extra fields go in an "extra" object.
+// UnmarshalJSON reads it back.
+func (r DnsRecord) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonRecord{Record: r.Record, Zone: r.Zone, Type: r.ZoneType, Value: r.Value, Editable: r.Editable, Comment: r.Comment, AccountId: r.AccountId, Extra: r.Extra})
+}
+
+// parseEditable converts the editable field of a record, as decoded into an any, to a bool.
+func parseEditable(v any) (bool, error) {
+	switch v := v.(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0, nil
+	case string:
+		switch v {
+		case "1", "true":
+			return true, nil
+		case "0", "false", "":
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("editable %v is not 0 or 1", v)
+}
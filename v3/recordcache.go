@@ -0,0 +1,55 @@
+package dreamhostapi
+
+import (
+	"context"
+	"time"
+)
+
+// cachedRecords is the Client's cache of the account's record listing.
+type cachedRecords struct {
+	records    DnsRecords
+	fetched    time.Time
+	generation int // counts the invalidations, so a listing fetched across a change isn't cached
+}
+
+// GetDNSRecordsByZone returns the DNS records in zone, ignoring case and any trailing dot, and any errors.
+// It filters the account's full listing, which is reused for as long as WithRecordCache allows.
+func (c *Client) GetDNSRecordsByZone(ctx context.Context, zone string) (DnsRecords, error) {
+	records, err := c.listRecords(ctx)
+	if err != nil {
+		return DnsRecords{}, err
+	}
+	return records.ByZone(zone), nil
+}
+
+// listRecords returns the account's records from GetDNSRecords, using the record cache when it is on and fresh.
+// The returned records share their backing array with the cache and must not be changed in place.
+func (c *Client) listRecords(ctx context.Context) (DnsRecords, error) {
+	if c.recordTTL <= 0 {
+		return c.GetDNSRecords(ctx)
+	}
+	c.recordCacheMu.Lock()
+	cached := c.recordCache
+	c.recordCacheMu.Unlock()
+	if !cached.fetched.IsZero() && c.clock.Now().Sub(cached.fetched) < c.recordTTL {
+		return cached.records, nil
+	}
+	records, err := c.GetDNSRecords(ctx)
+	if err != nil {
+		return DnsRecords{}, err
+	}
+	c.recordCacheMu.Lock()
+	if c.recordCache.generation == cached.generation {
+		c.recordCache = cachedRecords{records: records, fetched: c.clock.Now(), generation: cached.generation}
+	}
+	c.recordCacheMu.Unlock()
+	return records, nil
+}
+
+// invalidateRecords drops the record cache, as a command changes the account.
+// Bumping the generation also stops a listing that was being fetched meanwhile from being cached.
+func (c *Client) invalidateRecords() {
+	c.recordCacheMu.Lock()
+	c.recordCache = cachedRecords{generation: c.recordCache.generation + 1}
+	c.recordCacheMu.Unlock()
+}
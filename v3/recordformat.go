@@ -0,0 +1,56 @@
+package dreamhostapi
+
+import (
+	"strings"
+	"text/template"
+)
+
+// A RecordFormatter renders a record as text, eg for logs and reports. Use one with DnsRecord.Render.
+type RecordFormatter func(DnsRecord) string
+
+// DefaultRecordFormat renders a record in the multi-line layout of DnsRecord.String.
+func DefaultRecordFormat(r DnsRecord) string {
+	return r.String()
+}
+
+// Render returns the record as format renders it, eg with a formatter from TemplateFormat.
+func (r DnsRecord) Render(format RecordFormatter) string {
+	return format(r)
+}
+
+// TemplateFormat returns a RecordFormatter that executes the text/template text for a record, and any error parsing text.
+// The template can use the record's fields and its FQDN, UnicodeName, IsApex, and IsWildcard methods, eg "{{.FQDN}} {{.ZoneType}} {{.Value}}".
+// If executing the template fails, the formatter returns the error in the style of fmt, eg "%!(template: ...)".
+func TemplateFormat(text string) (RecordFormatter, error) {
+	tmpl, err := template.New("record").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return func(r DnsRecord) string {
+		var out strings.Builder
+		if err := tmpl.Execute(&out, templateRecord(r)); err != nil {
+			return "%!(" + err.Error() + ")"
+		}
+		return out.String()
+	}, nil
+}
+
+// templateRecord is the data TemplateFormat's templates get. It has the fields of DnsRecord but not its String method,
+// so a template that prints the record itself, as {{.}} does, can't recurse into a formatter.
+type templateRecord DnsRecord
+
+func (r templateRecord) FQDN() string {
+	return DnsRecord(r).FQDN()
+}
+
+func (r templateRecord) UnicodeName() string {
+	return DnsRecord(r).UnicodeName()
+}
+
+func (r templateRecord) IsApex() bool {
+	return DnsRecord(r).IsApex()
+}
+
+func (r templateRecord) IsWildcard() bool {
+	return DnsRecord(r).IsWildcard()
+}
@@ -0,0 +1,128 @@
+package dreamhostapi
+
+import (
+	"slices"
+	"strings"
+)
+
+// Filter returns the records for which keep returns true.
+func (records DnsRecords) Filter(keep func(DnsRecord) bool) DnsRecords {
+	matches := DnsRecords{Result: records.Result}
+	for _, record := range records.Data {
+		if keep(record) {
+			matches.Data = append(matches.Data, record)
+		}
+	}
+	return matches
+}
+
+// ByZone returns the records in zone, ignoring case and any trailing dot.
+func (records DnsRecords) ByZone(zone string) DnsRecords {
+	zone = strings.TrimSuffix(zone, ".")
+	return records.Filter(func(record DnsRecord) bool {
+		return sameName(record.Zone, zone)
+	})
+}
+
+// ByType returns the records of type recordType.
+func (records DnsRecords) ByType(recordType RecordType) DnsRecords {
+	return records.Filter(func(record DnsRecord) bool {
+		return record.ZoneType == recordType
+	})
+}
+
+// ByValue returns the records whose value is value.
+func (records DnsRecords) ByValue(value string) DnsRecords {
+	return records.Filter(func(record DnsRecord) bool {
+		return record.Value == value
+	})
+}
+
+// ByAccount returns the records that belong to the account with the ID account.
+func (records DnsRecords) ByAccount(account string) DnsRecords {
+	return records.Filter(func(record DnsRecord) bool {
+		return record.AccountId == account
+	})
+}
+
+// Find returns the first record named name of type recordType and whether there was one. See DnsRecord.Matches.
+func (records DnsRecords) Find(name string, recordType RecordType) (DnsRecord, bool) {
+	for _, record := range records.Data {
+		if record.Matches(name, recordType) {
+			return record, true
+		}
+	}
+	return DnsRecord{}, false
+}
+
+// zoneKey returns the record's zone lowercase and without a trailing dot, as GroupByZone and Stats key zones.
+func (r DnsRecord) zoneKey() string {
+	return strings.ToLower(strings.TrimSuffix(r.Zone, "."))
+}
+
+// GroupByZone returns the records indexed by zone, with the zone names lowercased and without a trailing dot.
+// Within each zone the records keep their order.
+func (records DnsRecords) GroupByZone() map[string][]DnsRecord {
+	groups := make(map[string][]DnsRecord)
+	for _, record := range records.Data {
+		zone := record.zoneKey()
+		groups[zone] = append(groups[zone], record)
+	}
+	return groups
+}
+
+// GroupByType returns the records indexed by type. Within each type the records keep their order.
+func (records DnsRecords) GroupByType() map[RecordType][]DnsRecord {
+	groups := make(map[RecordType][]DnsRecord)
+	for _, record := range records.Data {
+		groups[record.ZoneType] = append(groups[record.ZoneType], record)
+	}
+	return groups
+}
+
+// CompareRecords orders records by zone, then record name, then type, then value, ignoring case in the names.
+// It returns a negative number if a sorts before b, a positive number if after, and 0 if they are in the same place.
+func CompareRecords(a DnsRecord, b DnsRecord) int {
+	if c := strings.Compare(strings.ToLower(a.Zone), strings.ToLower(b.Zone)); c != 0 {
+		return c
+	}
+	if c := strings.Compare(strings.ToLower(a.Record), strings.ToLower(b.Record)); c != 0 {
+		return c
+	}
+	if c := strings.Compare(string(a.ZoneType), string(b.ZoneType)); c != 0 {
+		return c
+	}
+	return strings.Compare(a.Value, b.Value)
+}
+
+// Sort sorts the records in place by CompareRecords, so listings of the same records always come out in the same order.
+func (records DnsRecords) Sort() {
+	records.SortFunc(CompareRecords)
+}
+
+// SortFunc sorts the records in place by cmp, keeping records that cmp puts in the same place in their original order.
+func (records DnsRecords) SortFunc(cmp func(a, b DnsRecord) int) {
+	slices.SortStableFunc(records.Data, cmp)
+}
+
+// Duplicates returns the groups of records that share a name, type, and value, ignoring case and any trailing dot in the name.
+// Dreamhost accepts duplicate records, and repeated updates can pile them up. Each group holds every copy, in listing order,
+// and the groups are in the order their first copy appears.
+func (records DnsRecords) Duplicates() [][]DnsRecord {
+	groups := make(map[recordKey][]DnsRecord)
+	var order []recordKey
+	for _, record := range records.Data {
+		key := record.key()
+		if _, seen := groups[key]; !seen {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], record)
+	}
+	var duplicates [][]DnsRecord
+	for _, key := range order {
+		if len(groups[key]) > 1 {
+			duplicates = append(duplicates, groups[key])
+		}
+	}
+	return duplicates
+}
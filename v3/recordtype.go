@@ -0,0 +1,56 @@
+package dreamhostapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A RecordType is the type of a DNS record, eg A or TXT.
+type RecordType string
+
+// The record types Dreamhost supports.
+const (
+	A     RecordType = "A"
+	AAAA  RecordType = "AAAA"
+	CNAME RecordType = "CNAME"
+	MX    RecordType = "MX" // listed by dns-list_records, but only managed through the Dreamhost panel, so not in RecordTypes
+	NAPTR RecordType = "NAPTR"
+	NS    RecordType = "NS"
+	SRV   RecordType = "SRV"
+	TXT   RecordType = "TXT"
+)
+
+// RecordTypes are the record types dns-add_record and dns-remove_record accept.
+var RecordTypes = []RecordType{A, AAAA, CNAME, NAPTR, NS, SRV, TXT}
+
+// ParseRecordType returns the RecordType named by s, ignoring case and surrounding space, or an error if Dreamhost doesn't support it.
+func ParseRecordType(s string) (RecordType, error) {
+	t := RecordType(strings.ToUpper(strings.TrimSpace(s)))
+	if !t.Valid() {
+		return "", fmt.Errorf("record type %q is not one of %s", s, recordTypeList())
+	}
+	return t, nil
+}
+
+// Valid reports whether t is one of RecordTypes.
+func (t RecordType) Valid() bool {
+	for _, recordType := range RecordTypes {
+		if t == recordType {
+			return true
+		}
+	}
+	return false
+}
+
+func (t RecordType) String() string {
+	return string(t)
+}
+
+// recordTypeList returns RecordTypes as a comma-separated list.
+func recordTypeList() string {
+	names := make([]string, len(RecordTypes))
+	for i, recordType := range RecordTypes {
+		names[i] = string(recordType)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,30 @@
+package dreamhostapi
+
+import "golang.org/x/net/publicsuffix"
+
+// RegistrableZone returns the zone that host can be registered under according to the public suffix list,
+// eg foo.co.uk for www.foo.co.uk, and any errors. host is normalized with NormalizeName first.
+// A host that is itself a public suffix, eg co.uk, has no registrable zone and returns a ValidationError.
+func RegistrableZone(host string) (string, error) {
+	name := NormalizeName(host)
+	zone, err := publicsuffix.EffectiveTLDPlusOne(name)
+	if err != nil {
+		return "", &ValidationError{Field: "name", Value: host, Reason: "has no registrable zone: " + err.Error()}
+	}
+	return zone, nil
+}
+
+// GroupByRegistrableZone returns the records grouped by the RegistrableZone of their name,
+// so records of delegated subzones are grouped with their parent domain.
+// A record whose name has no registrable zone is grouped under its Zone.
+func (records DnsRecords) GroupByRegistrableZone() map[string][]DnsRecord {
+	groups := make(map[string][]DnsRecord)
+	for _, record := range records.Data {
+		zone, err := RegistrableZone(record.Record)
+		if err != nil {
+			zone = record.zoneKey()
+		}
+		groups[zone] = append(groups[zone], record)
+	}
+	return groups
+}
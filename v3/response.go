@@ -0,0 +1,50 @@
+package dreamhostapi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// A Response is the result/data envelope every Dreamhost API response comes in, with the data decoded as T.
+type Response[T any] struct {
+	Result string `json:"result"`           // "success" or "error"
+	Data   T      `json:"data"`             // the command's output
+	Reason string `json:"reason,omitempty"` // a longer explanation some errors come with
+}
+
+// An Envelope is a Response with its data left undecoded.
+type Envelope = Response[json.RawMessage]
+
+// decodeResponse unmarshals a response body from the Dreamhost API and checks its result.
+// If the API reports an error, the response is returned with its data left as the zero value,
+// along with an *APIError holding the command and the error code from the data field.
+func decodeResponse[T any](c *Client, command string, body string) (Response[T], error) {
+	var envelope Envelope
+	response := Response[T]{}
+	if err := c.decode(body, &envelope); err != nil {
+		return response, err
+	}
+	response.Result, response.Reason = envelope.Result, envelope.Reason
+	if envelope.Result != "success" {
+		var code string
+		if c.decoder.Decode(envelope.Data, &code) != nil {
+			code = string(envelope.Data)
+		}
+		return response, &APIError{Command: command, Code: code, Reason: envelope.Reason}
+	}
+	if len(envelope.Data) > 0 {
+		if err := c.decoder.Decode(envelope.Data, &response.Data); err != nil {
+			return response, err
+		}
+	}
+	return response, nil
+}
+
+// submitCommand sends command and decodes the response's data as T.
+func submitCommand[T any](ctx context.Context, c *Client, command map[string]string) (Response[T], error) {
+	body, err := c.submitDreamhostCommand(ctx, command)
+	if err != nil {
+		return Response[T]{}, err
+	}
+	return decodeResponse[T](c, command["cmd"], body)
+}
@@ -0,0 +1,35 @@
+package dreamhostapi
+
+import (
+	"net/netip"
+	"strings"
+)
+
+// PointingTo returns the records that reference the IP address ip, eg to find what still uses a server that is being retired.
+// A record references ip if its value is the address, in any notation, or if a word of its value is the address or a network holding it,
+// optionally after an ip4: or ip6: prefix, as in an SPF record.
+// If ip isn't an IP address, PointingTo returns the records whose value is ip, as ByValue does.
+func (records DnsRecords) PointingTo(ip string) DnsRecords {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return records.ByValue(ip)
+	}
+	addr = addr.Unmap()
+	return records.Filter(func(record DnsRecord) bool {
+		return referencesAddr(record.Value, addr)
+	})
+}
+
+// referencesAddr reports whether a word of value is addr or a network holding it, optionally after an ip4: or ip6: prefix.
+func referencesAddr(value string, addr netip.Addr) bool {
+	for _, word := range strings.Fields(JoinTXT(value)) {
+		word = strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(word), "ip4:"), "ip6:")
+		if other, err := netip.ParseAddr(word); err == nil && other.Unmap() == addr {
+			return true
+		}
+		if prefix, err := netip.ParsePrefix(word); err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package dreamhostapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// An AccessibleCommand is a command an API key may run, as listed by api-list_accessible_cmds.
+type AccessibleCommand struct {
+	Cmd     string   // the command name, eg dns-list_records
+	Args    []string // required parameters
+	Optargs []string // optional parameters
+	Order   []string // the parameters in their documented order
+}
+
+// A KeyScope is the set of commands an API key may run.
+type KeyScope struct {
+	Commands []string // the command names, sorted
+}
+
+// ListAccessibleCommands returns the commands the Client's API key may run and any errors.
+func (c *Client) ListAccessibleCommands(ctx context.Context) ([]AccessibleCommand, error) {
+	command := map[string]string{"cmd": "api-list_accessible_cmds"}
+	response, err := submitCommand[[]AccessibleCommand](ctx, c, command)
+	if err != nil {
+		return nil, err
+	}
+	return response.Data, nil
+}
+
+// ValidateAPIKey checks that the Client's API key works and returns the commands it may run.
+// A key the API rejects is reported as an *APIError holding the API's error code, eg invalid_api_key.
+func (c *Client) ValidateAPIKey(ctx context.Context) (KeyScope, error) {
+	commands, err := c.ListAccessibleCommands(ctx)
+	if err != nil {
+		return KeyScope{}, err
+	}
+	var scope KeyScope
+	for _, command := range commands {
+		scope.Commands = append(scope.Commands, command.Cmd)
+	}
+	sort.Strings(scope.Commands)
+	return scope, nil
+}
+
+// Allows reports whether cmd is one of the commands in the scope.
+func (s KeyScope) Allows(cmd string) bool {
+	i := sort.SearchStrings(s.Commands, cmd)
+	return i < len(s.Commands) && s.Commands[i] == cmd
+}
+
+// Families returns the command families in the scope, sorted, eg "dns" for dns-list_records and dns-add_record.
+func (s KeyScope) Families() []string {
+	var families []string
+	seen := make(map[string]bool)
+	for _, cmd := range s.Commands {
+		family, _, _ := strings.Cut(cmd, "-")
+		if !seen[family] {
+			seen[family] = true
+			families = append(families, family)
+		}
+	}
+	sort.Strings(families)
+	return families
+}
+
+// ErrInsufficientScope is returned when the API key is not allowed to run a command.
+var ErrInsufficientScope = errors.New("API key is not allowed to run this command")
+
+// An InsufficientScopeError reports a command the Client's API key may not run, found by the scope pre-flight before the command was sent.
+type InsufficientScopeError struct {
+	Command string // the command that was refused
+}
+
+func (e *InsufficientScopeError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInsufficientScope, e.Command)
+}
+
+func (e *InsufficientScopeError) Unwrap() error {
+	return ErrInsufficientScope
+}
+
+// keyScope is the Client's cache of its API key's scope.
+type keyScope struct {
+	scope   KeyScope
+	fetched time.Time
+}
+
+// checkScope returns an InsufficientScopeError if command changes the account and the API key may not run it.
+// The scope is cached for the same time as the hosted domains; see WithZoneCacheTTL.
+func (c *Client) checkScope(ctx context.Context, command string) error {
+	spec, ok := LookupCommand(command)
+	if !ok || !spec.Mutating {
+		return nil
+	}
+	c.scopeCacheMu.Lock()
+	cached := c.scopeCache
+	c.scopeCacheMu.Unlock()
+	if cached.fetched.IsZero() || c.clock.Now().Sub(cached.fetched) >= c.zoneCacheTTL {
+		scope, err := c.ValidateAPIKey(ctx)
+		if err != nil {
+			return err
+		}
+		cached = keyScope{scope: scope, fetched: c.clock.Now()}
+		c.scopeCacheMu.Lock()
+		c.scopeCache = cached
+		c.scopeCacheMu.Unlock()
+	}
+	if !cached.scope.Allows(command) {
+		return &InsufficientScopeError{Command: command}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package dreamhostapi
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Search returns the records whose name or value matches pattern, and any error in pattern.
+// A pattern between slashes, eg /^mail[0-9]+\./, is a regular expression that may match any part of the name or value.
+// Any other pattern is a glob, eg *.blog.example.com, that must match the whole name or value, ignoring case;
+// * matches any run of characters other than a slash, dots included, ? one character, and [...] a class of characters, as in path.Match.
+func (records DnsRecords) Search(pattern string) (DnsRecords, error) {
+	var match func(string) bool
+	if len(pattern) >= 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		re, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return DnsRecords{}, fmt.Errorf("invalid search pattern %q: %w", pattern, err)
+		}
+		match = re.MatchString
+	} else {
+		glob := strings.ToLower(pattern)
+		if _, err := path.Match(glob, ""); err != nil {
+			return DnsRecords{}, fmt.Errorf("invalid search pattern %q: %w", pattern, err)
+		}
+		match = func(s string) bool {
+			matched, _ := path.Match(glob, strings.ToLower(s))
+			return matched
+		}
+	}
+	return records.Filter(func(record DnsRecord) bool {
+		return match(record.Record) || match(record.Value)
+	}), nil
+}
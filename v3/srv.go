@@ -0,0 +1,80 @@
+package dreamhostapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An SRVRecord is the value of an SRV record: where a service is offered and how to choose between servers.
+type SRVRecord struct {
+	Priority int    // lower values are tried first
+	Weight   int    // shares load between servers of the same priority
+	Port     int    // the port the service listens on
+	Target   string // the hostname of the server, or "." if the service is not offered
+}
+
+// ParseSRV parses an SRV record value such as "10 5 5060 sip.example.com".
+func ParseSRV(value string) (SRVRecord, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		return SRVRecord{}, fmt.Errorf("SRV value %q is not in the form \"priority weight port target\"", value)
+	}
+	var numbers [3]int
+	for i, name := range []string{"priority", "weight", "port"} {
+		number, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return SRVRecord{}, fmt.Errorf("SRV value %q has an invalid %s: %w", value, name, err)
+		}
+		numbers[i] = number
+	}
+	srv := SRVRecord{Priority: numbers[0], Weight: numbers[1], Port: numbers[2], Target: fields[3]}
+	return srv, srv.Validate()
+}
+
+// String returns the SRV record value in the form Dreamhost expects, eg "10 5 5060 sip.example.com".
+func (srv SRVRecord) String() string {
+	return fmt.Sprintf("%d %d %d %s", srv.Priority, srv.Weight, srv.Port, srv.Target)
+}
+
+// Validate checks that the numbers are in range and the target is a valid hostname or ".".
+func (srv SRVRecord) Validate() error {
+	for _, field := range []struct {
+		name  string
+		value int
+	}{{"priority", srv.Priority}, {"weight", srv.Weight}, {"port", srv.Port}} {
+		if field.value < 0 || field.value > 65535 {
+			return fmt.Errorf("SRV %s %d is out of range 0-65535", field.name, field.value)
+		}
+	}
+	if srv.Target != "." && !validHostname(srv.Target) {
+		return fmt.Errorf("SRV target %q is not a valid hostname", srv.Target)
+	}
+	return nil
+}
+
+// NewSRV returns the SRV record for service over proto on domain, named _service._proto.domain,
+// eg NewSRV("sip", "tcp", "example.com", srv) for _sip._tcp.example.com.
+// service and proto may be given with or without their leading underscore.
+func NewSRV(service string, proto string, domain string, srv SRVRecord) (DnsRecord, error) {
+	name := "_" + strings.TrimPrefix(service, "_") + "._" + strings.TrimPrefix(proto, "_") + "." + domain
+	if err := validSRVName(name); err != nil {
+		return DnsRecord{}, err
+	}
+	if err := srv.Validate(); err != nil {
+		return DnsRecord{}, err
+	}
+	return DnsRecord{Record: name, ZoneType: SRV, Value: srv.String()}, nil
+}
+
+// validSRVName checks that name follows the _service._proto.domain convention.
+func validSRVName(name string) error {
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	if len(labels) < 3 || len(labels[0]) < 2 || len(labels[1]) < 2 || labels[0][0] != '_' || labels[1][0] != '_' {
+		return fmt.Errorf("SRV name %q is not in the form _service._proto.domain", name)
+	}
+	if !validHostname(name) {
+		return fmt.Errorf("SRV name %q is not a valid hostname", name)
+	}
+	return nil
+}
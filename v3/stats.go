@@ -0,0 +1,31 @@
+package dreamhostapi
+
+import (
+	"maps"
+	"slices"
+)
+
+// Stats summarizes a set of records.
+type Stats struct {
+	Total    int                // the number of records
+	Editable int                // how many of them can be changed through the API
+	ByType   map[RecordType]int // the number of records of each type
+	ByZone   map[string]int     // the number of records in each zone, keyed lowercase without a trailing dot as in GroupByZone
+	Values   []string           // the distinct values the records point to, sorted
+}
+
+// Stats returns the counts of the records by type and by zone, and their distinct values.
+func (records DnsRecords) Stats() Stats {
+	stats := Stats{Total: len(records.Data), ByType: make(map[RecordType]int), ByZone: make(map[string]int)}
+	values := make(map[string]bool)
+	for _, record := range records.Data {
+		if record.Editable {
+			stats.Editable++
+		}
+		stats.ByType[record.ZoneType]++
+		stats.ByZone[record.zoneKey()]++
+		values[record.Value] = true
+	}
+	stats.Values = slices.Sorted(maps.Keys(values))
+	return stats
+}
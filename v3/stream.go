@@ -0,0 +1,137 @@
+package dreamhostapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// errStopStream is returned by the callback DNSRecords gives StreamDNSRecords when the loop over the records stops early.
+var errStopStream = errors.New("stream stopped")
+
+// StreamDNSRecords calls fn with each DNS record on the account as it is decoded from the response,
+// without holding the whole response or record list in memory, for accounts with tens of thousands of records.
+// It stops and returns the error if fn returns one. Errors the API reports are returned as *APIError, as by GetDNSRecords.
+// If the Client has an account scope, only the records of that account are passed to fn; see WithAccountScope.
+// Responses are decoded with encoding/json as they arrive rather than with the Client's Decoder.
+// A Client using a format other than FormatJSON can't stream, and fetches the records with GetDNSRecords instead.
+func (c *Client) StreamDNSRecords(ctx context.Context, fn func(DnsRecord) error) error {
+	command := map[string]string{"cmd": "dns-list_records"}
+	keep := func(record DnsRecord) error {
+		if c.accountScope != "" && record.AccountId != c.accountScope {
+			return nil
+		}
+		return fn(record)
+	}
+	if c.format != FormatJSON {
+		records, err := c.GetDNSRecords(ctx)
+		if err != nil {
+			return err
+		}
+		for _, record := range records.Data {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	response, err := c.openCommand(ctx, command)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	c.responded(command["cmd"], response.StatusCode, nil, nil)
+	return streamRecords(command["cmd"], json.NewDecoder(response.Body), keep)
+}
+
+// DNSRecords returns an iterator over the DNS records on the account, streamed as by StreamDNSRecords.
+// If fetching or decoding the records fails, the iterator yields a zero DnsRecord with the error and stops.
+func (c *Client) DNSRecords(ctx context.Context) iter.Seq2[DnsRecord, error] {
+	return func(yield func(DnsRecord, error) bool) {
+		err := c.StreamDNSRecords(ctx, func(record DnsRecord) error {
+			if !yield(record, nil) {
+				return errStopStream
+			}
+			return nil
+		})
+		if err != nil && !errors.Is(err, errStopStream) {
+			yield(DnsRecord{}, err)
+		}
+	}
+}
+
+// streamRecords reads a dns-list_records response envelope from decoder, calling fn with each record of its data.
+func streamRecords(command string, decoder *json.Decoder, fn func(DnsRecord) error) error {
+	if err := expectDelim(decoder, '{'); err != nil {
+		return err
+	}
+	var result, reason, code string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case "result":
+			err = decoder.Decode(&result)
+		case "reason":
+			err = decoder.Decode(&reason)
+		case "data":
+			err = streamData(decoder, &code, fn)
+		default:
+			var skipped json.RawMessage
+			err = decoder.Decode(&skipped)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := expectDelim(decoder, '}'); err != nil {
+		return err
+	}
+	if result != "success" {
+		return &APIError{Command: command, Code: code, Reason: reason}
+	}
+	return nil
+}
+
+// streamData reads the data of a response envelope from decoder. A list is passed to fn a record at a time;
+// anything else, as the API sends for errors, is stored in code.
+func streamData(decoder *json.Decoder, code *string, fn func(DnsRecord) error) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case json.Delim('['):
+	case json.Delim('{'):
+		return errors.New("invalid response: data is an object")
+	default:
+		*code = fmt.Sprint(token)
+		return nil
+	}
+	for decoder.More() {
+		var record DnsRecord
+		if err := decoder.Decode(&record); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return expectDelim(decoder, ']')
+}
+
+// expectDelim reads the next token from decoder and returns an error unless it is delim.
+func expectDelim(decoder *json.Decoder, delim json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("invalid response: expected %v, got %v", delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package dreamhostapi
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// TableOptions changes what DnsRecords.Table writes. The zero value writes every record, with a header, in the order they are in.
+type TableOptions struct {
+	Filter   func(DnsRecord) bool // if set, only the records it keeps are written
+	Sorted   bool                 // write the records in the order of DnsRecords.Sort, without changing records
+	Unicode  bool                 // write names as Unicode rather than punycode; see ToUnicode
+	NoHeader bool                 // leave out the header line
+}
+
+// Table writes the records to w as a table with aligned columns for the name, type, value, zone, whether it is editable, and comment,
+// one record per line. It returns any error from writing to w.
+func (records DnsRecords) Table(w io.Writer, opts TableOptions) error {
+	rows := records
+	if opts.Filter != nil {
+		rows = records.Filter(opts.Filter)
+	}
+	if opts.Sorted {
+		rows.Data = append([]DnsRecord(nil), rows.Data...)
+		rows.Sort()
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if !opts.NoHeader {
+		fmt.Fprintln(tw, "RECORD\tTYPE\tVALUE\tZONE\tEDITABLE\tCOMMENT")
+	}
+	for _, record := range rows.Data {
+		name, zone := record.Record, record.Zone
+		if opts.Unicode {
+			name, zone = ToUnicode(name), ToUnicode(zone)
+		}
+		editable := "no"
+		if record.Editable {
+			editable = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", name, record.ZoneType, tableCell(record.Value), zone, editable, tableCell(record.Comment))
+	}
+	return tw.Flush()
+}
+
+// tableCell returns s with tabs and newlines replaced by spaces, so it can't break the table's columns.
+func tableCell(s string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(s)
+}
@@ -0,0 +1,74 @@
+package dreamhostapi
+
+import (
+	"slices"
+	"strings"
+)
+
+// A RecordNode is a name in a RecordTree, holding the records at that name and the names one label below it.
+type RecordNode struct {
+	Name     string        // the full name, eg staging.blog.example.com
+	Label    string        // the leftmost label of Name, eg staging, or the zone itself at the root
+	Records  []DnsRecord   // the records at Name, which may be none for a name that only has names below it
+	Children []*RecordNode // the names one label below Name, sorted by label
+}
+
+// Tree returns the records in zone organized by label, eg example.com, then blog.example.com, then staging.blog.example.com,
+// so whole subtrees can be shown or pruned. Names are compared ignoring case and any trailing dot.
+// Records outside zone are left out; the root is zone, with no records if there are none at the apex.
+func (records DnsRecords) Tree(zone string) *RecordNode {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	root := &RecordNode{Name: zone, Label: zone}
+	for _, record := range records.Data {
+		name := strings.ToLower(strings.TrimSuffix(record.Record, "."))
+		if name != zone && !strings.HasSuffix(name, "."+zone) {
+			continue
+		}
+		node := root
+		if name != zone {
+			labels := strings.Split(strings.TrimSuffix(name, "."+zone), ".")
+			for i := len(labels) - 1; i >= 0; i-- {
+				node = node.child(labels[i])
+			}
+		}
+		node.Records = append(node.Records, record)
+	}
+	return root
+}
+
+// child returns the node for the name label below n, adding it in label order if it doesn't exist yet.
+func (n *RecordNode) child(label string) *RecordNode {
+	i, found := slices.BinarySearchFunc(n.Children, label, func(child *RecordNode, label string) int {
+		return strings.Compare(child.Label, label)
+	})
+	if !found {
+		n.Children = slices.Insert(n.Children, i, &RecordNode{Name: label + "." + n.Name, Label: label})
+	}
+	return n.Children[i]
+}
+
+// Walk calls fn with n and every node below it, parents before their children and siblings in label order,
+// with depth 0 for n. It doesn't descend below a node for which fn returns false.
+func (n *RecordNode) Walk(fn func(node *RecordNode, depth int) bool) {
+	n.walk(fn, 0)
+}
+
+// walk does the work of Walk, with n at depth.
+func (n *RecordNode) walk(fn func(node *RecordNode, depth int) bool, depth int) {
+	if !fn(n, depth) {
+		return
+	}
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// All returns the records at n and at every name below it, eg to delete a whole subtree.
+func (n *RecordNode) All() DnsRecords {
+	var all DnsRecords
+	n.Walk(func(node *RecordNode, _ int) bool {
+		all.Data = append(all.Data, node.Records...)
+		return true
+	})
+	return all
+}
@@ -0,0 +1,51 @@
+package dreamhostapi
+
+import "strings"
+
+// MaxTXTChunk is the most bytes a single character-string in a TXT record can hold.
+const MaxTXTChunk = 255
+
+// ChunkTXT returns value as a TXT record value made of quoted strings of at most MaxTXTChunk bytes each,
+// eg for a long DKIM key. Quotes and backslashes in value are escaped.
+func ChunkTXT(value string) string {
+	var chunks []string
+	for len(value) > MaxTXTChunk {
+		chunks = append(chunks, quoteTXT(value[:MaxTXTChunk]))
+		value = value[MaxTXTChunk:]
+	}
+	chunks = append(chunks, quoteTXT(value))
+	return strings.Join(chunks, " ")
+}
+
+// quoteTXT returns s as a quoted TXT character-string.
+func quoteTXT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// JoinTXT reassembles a TXT record value made by ChunkTXT, or any value of quoted strings, into the text it holds.
+// A value that doesn't start with a quote is returned as it is.
+func JoinTXT(value string) string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, `"`) {
+		return value
+	}
+	var text strings.Builder
+	quoted, escaped := false, false
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case escaped:
+			text.WriteByte(ch)
+			escaped = false
+		case quoted && ch == '\\':
+			escaped = true
+		case ch == '"':
+			quoted = !quoted
+		case quoted:
+			text.WriteByte(ch)
+		}
+	}
+	return text.String()
+}
@@ -0,0 +1,58 @@
+package dreamhostapi
+
+import "net/netip"
+
+// MaxTXTLength is the longest TXT value ValidateRecord accepts. Longer values may not fit in a DNS response.
+const MaxTXTLength = 4000
+
+// ValidateRecord checks that value is a sensible value for a record named name of type recordType,
+// eg that an A record holds an IPv4 address, and returns a ValidationError describing the first problem found.
+// AddRecord runs it on every record before calling the API.
+func ValidateRecord(name string, recordType RecordType, value string) error {
+	invalid := func(field string, value string, reason string) error {
+		return &ValidationError{Field: field, Value: value, Reason: reason}
+	}
+	if !recordType.Valid() {
+		return invalid("type", string(recordType), "is not one of "+recordTypeList())
+	}
+	if IsWildcard(name) {
+		if err := ValidateWildcard(name); err != nil {
+			return err
+		}
+	} else if !validHostname(name) {
+		return invalid("record", name, "is not a valid hostname")
+	}
+	switch recordType {
+	case A:
+		if address, err := netip.ParseAddr(value); err != nil || !address.Is4() {
+			return invalid("value", value, "is not an IPv4 address")
+		}
+	case AAAA:
+		if address, err := netip.ParseAddr(value); err != nil || !address.Is6() || address.Is4In6() {
+			return invalid("value", value, "is not an IPv6 address")
+		}
+	case CNAME, NS:
+		if !validHostname(value) {
+			return invalid("value", value, "is not a valid hostname")
+		}
+	case SRV:
+		if err := validSRVName(name); err != nil {
+			return invalid("record", name, "is not in the form _service._proto.domain")
+		}
+		if _, err := ParseSRV(value); err != nil {
+			return invalid("value", value, "is not a valid SRV value: "+err.Error())
+		}
+	case NAPTR:
+		if _, err := ParseNAPTR(value); err != nil {
+			return invalid("value", value, "is not a valid NAPTR value: "+err.Error())
+		}
+	case TXT:
+		if value == "" {
+			return invalid("value", value, "is empty")
+		}
+		if len(value) > MaxTXTLength {
+			return invalid("value", "", "is longer than the maximum TXT length")
+		}
+	}
+	return nil
+}
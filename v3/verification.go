@@ -0,0 +1,78 @@
+package dreamhostapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A VerificationTemplate returns the records a provider needs to see on domain before it accepts token as proof of ownership.
+type VerificationTemplate func(domain string, token string) []DnsRecord
+
+// VerificationTemplates maps provider names to the records they use for domain verification.
+// Add to it to support providers that aren't built in.
+var VerificationTemplates = map[string]VerificationTemplate{
+	"google":       txtVerification("google-site-verification="),
+	"microsoft365": microsoft365Verification,
+	"atlassian":    txtVerification("atlassian-domain-verification="),
+	"facebook":     txtVerification("facebook-domain-verification="),
+	"apple":        txtVerification("apple-domain-verification="),
+	"docusign":     txtVerification("docusign="),
+	"zoom":         txtVerification("ZOOM_verify_"),
+	"bing":         bingVerification,
+}
+
+// txtVerification builds a template for providers that want a TXT record on the domain holding prefix followed by the token.
+func txtVerification(prefix string) VerificationTemplate {
+	return func(domain string, token string) []DnsRecord {
+		return []DnsRecord{{Record: domain, ZoneType: TXT, Value: prefix + strings.TrimPrefix(token, prefix)}}
+	}
+}
+
+// microsoft365Verification accepts the token with or without its MS= prefix.
+func microsoft365Verification(domain string, token string) []DnsRecord {
+	return []DnsRecord{{Record: domain, ZoneType: TXT, Value: "MS=" + strings.TrimPrefix(token, "MS=")}}
+}
+
+// bingVerification points a CNAME named after the token at Bing's verification host.
+func bingVerification(domain string, token string) []DnsRecord {
+	return []DnsRecord{{Record: token + "." + domain, ZoneType: CNAME, Value: "verify.bing.com"}}
+}
+
+// VerificationProviders returns the names of the providers in VerificationTemplates, sorted.
+func VerificationProviders() []string {
+	providers := make([]string, 0, len(VerificationTemplates))
+	for provider := range VerificationTemplates {
+		providers = append(providers, provider)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// VerificationRecords returns the records provider needs on domain to verify token.
+func VerificationRecords(provider string, domain string, token string) ([]DnsRecord, error) {
+	template, ok := VerificationTemplates[strings.ToLower(provider)]
+	if !ok {
+		return nil, fmt.Errorf("unknown verification provider %q, known providers are %s", provider, strings.Join(VerificationProviders(), ", "))
+	}
+	if token == "" {
+		return nil, errors.New("verification token is empty")
+	}
+	return template(domain, token), nil
+}
+
+// ApplyVerification adds the records provider needs on domain to verify token, skipping any that already exist.
+func (c *Client) ApplyVerification(ctx context.Context, provider string, domain string, token string) error {
+	records, err := VerificationRecords(provider, domain, token)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if _, err := c.EnsureRecord(ctx, record.Record, record.ZoneType, record.Value, provider+" domain verification"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package dreamhostapi
+
+import (
+	"context"
+	"strings"
+)
+
+// IsWildcard reports whether name is a wildcard name such as *.example.com.
+func IsWildcard(name string) bool {
+	return strings.HasPrefix(name, "*.")
+}
+
+// ValidateWildcard returns a ValidationError unless name is a valid wildcard name: a single * as the leftmost label,
+// followed by a valid hostname, eg *.example.com.
+func ValidateWildcard(name string) error {
+	if !IsWildcard(name) {
+		return &ValidationError{Field: "record", Value: name, Reason: "is not a wildcard name; it must start with *."}
+	}
+	if !validHostname(strings.TrimPrefix(name, "*.")) {
+		return &ValidationError{Field: "record", Value: name, Reason: "may only have * as its whole leftmost label"}
+	}
+	return nil
+}
+
+// IsWildcard reports whether the record is a wildcard record.
+func (r DnsRecord) IsWildcard() bool {
+	return IsWildcard(r.Record)
+}
+
+// Wildcards returns the wildcard records.
+func (records DnsRecords) Wildcards() DnsRecords {
+	return records.Filter(DnsRecord.IsWildcard)
+}
+
+// FindWildcard returns the wildcard record of type recordType that would answer for name, and whether there is one.
+// That is the record *.parent for the closest parent of name that has one. A name with a record of its own is not covered
+// by a wildcard, so check with Find first.
+func (records DnsRecords) FindWildcard(name string, recordType RecordType) (DnsRecord, bool) {
+	labels := strings.Split(NormalizeName(name), ".")
+	for i := 1; i < len(labels); i++ {
+		if record, ok := records.Find("*."+strings.Join(labels[i:], "."), recordType); ok {
+			return record, true
+		}
+	}
+	return DnsRecord{}, false
+}
+
+// AddWildcard adds value to *.domain, covering every name under domain that has no records of its own.
+// The record is an A record unless WithType says otherwise.
+func (c *Client) AddWildcard(ctx context.Context, domain string, value string, opts ...RecordOption) (CommandResult, error) {
+	name := "*." + strings.TrimPrefix(domain, "*.")
+	if err := ValidateWildcard(name); err != nil {
+		return CommandResult{}, err
+	}
+	return c.AddRecord(ctx, name, value, opts...)
+}
@@ -0,0 +1,41 @@
+package dreamhostapi
+
+import "strings"
+
+// MarshalText returns the record as a line of a BIND zone file, eg "www.example.com. IN A 192.0.2.1",
+// so a zone can be exported by joining its records with newlines and read by other DNS tooling.
+// Host names in the value of CNAME, NS, MX, SRV, and NAPTR records are made absolute with a trailing dot,
+// and a TXT value that isn't already quoted is quoted with ChunkTXT. The line has no trailing newline.
+func (r DnsRecord) MarshalText() ([]byte, error) {
+	return []byte(r.FQDN() + " IN " + string(r.ZoneType) + " " + zoneFileValue(r.ZoneType, r.Value)), nil
+}
+
+// zoneFileValue returns value, of a record of type recordType, as it is written in a zone file.
+func zoneFileValue(recordType RecordType, value string) string {
+	value = strings.TrimSpace(value)
+	switch recordType {
+	case CNAME, NS:
+		return absoluteName(value)
+	case MX, SRV, NAPTR:
+		// the host name is the last field of each of these
+		fields := strings.Fields(value)
+		if len(fields) > 0 {
+			fields[len(fields)-1] = absoluteName(fields[len(fields)-1])
+		}
+		return strings.Join(fields, " ")
+	case TXT:
+		if strings.HasPrefix(value, `"`) {
+			return value
+		}
+		return ChunkTXT(value)
+	}
+	return value
+}
+
+// absoluteName returns name with a trailing dot. "." and names that already have one are returned as they are.
+func absoluteName(name string) string {
+	if name == "" || strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}